@@ -11,6 +11,7 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/essentialkaos/ek/v13/sortutil"
 	"github.com/essentialkaos/ek/v13/strutil"
@@ -38,9 +39,13 @@ type Report struct {
 	Errors        Alerts   `json:"errors,omitempty"`
 	Criticals     Alerts   `json:"criticals,omitempty"`
 	IgnoredChecks []string `json:"ignored_checks,omitempty"`
+	Checks        []string `json:"-"` // IDs of executed checks
+	Policy        string   `json:"policy,omitempty"`
 	NoLint        bool     `json:"no_lint"`
 	IsPerfect     bool     `json:"is_perfect"`
 	IsSkipped     bool     `json:"is_skipped"`
+
+	Spec *spec.Spec `json:"-"` // Checked spec (original spec for build variants)
 }
 
 // Alert contains basic alert info
@@ -49,6 +54,10 @@ type Alert struct {
 	Level     uint8     `json:"level"`
 	Info      string    `json:"info"`
 	Line      spec.Line `json:"line"`
+	Fix       string    `json:"fix,omitempty"`
+	Column    int       `json:"column,omitempty"`     // First column of problem (1-based, 0 if unknown)
+	EndColumn int       `json:"end_column,omitempty"` // Last column of problem (inclusive)
+	EndLine   int       `json:"end_line,omitempty"`   // Index of last line of multi-line problem
 	IsIgnored bool      `json:"is_ignored"`
 }
 
@@ -69,7 +78,41 @@ var osInfoFunc = system.GetOSInfo
 
 // NewAlert creates new alert
 func NewAlert(id string, level uint8, info string, line spec.Line) Alert {
-	return Alert{id, level, info, line, false}
+	return Alert{ID: id, Level: level, Info: info, Line: line}
+}
+
+// NewAlertWithFix creates new alert with suggested replacement for the line
+func NewAlertWithFix(id string, level uint8, info string, line spec.Line, fix string) Alert {
+	return Alert{ID: id, Level: level, Info: info, Line: line, Fix: fix}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// WithSpan returns copy of alert with columns of given bytes range [start, end)
+// of the line text
+func (a Alert) WithSpan(start, end int) Alert {
+	if start < 0 || end > len(a.Line.Text) || start >= end {
+		return a
+	}
+
+	a.Column = utf8.RuneCountInString(a.Line.Text[:start]) + 1
+	a.EndColumn = utf8.RuneCountInString(a.Line.Text[:end])
+
+	return a
+}
+
+// WithEndLine returns copy of alert with the last line of multi-line problem
+func (a Alert) WithEndLine(line spec.Line) Alert {
+	if line.Index > a.Line.Index {
+		a.EndLine = line.Index
+	}
+
+	return a
+}
+
+// HasSpan returns true if alert contains columns range
+func (a Alert) HasSpan() bool {
+	return a.Column > 0 && a.EndColumn >= a.Column
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -166,7 +209,12 @@ func (a Alerts) Ignored() int {
 
 // Check executes different checks over given spec
 func Check(s *spec.Spec, lint bool, linterConfig string, ignored []string) *Report {
-	report := &Report{NoLint: !lint, IgnoredChecks: ignored}
+	report := &Report{
+		NoLint:        !lint,
+		IgnoredChecks: ignored,
+		Policy:        policy.Name,
+		Spec:          getOriginSpec(s),
+	}
 
 	if !isApplicableTarget(s) {
 		report.IsSkipped = true
@@ -178,39 +226,39 @@ func Check(s *spec.Spec, lint bool, linterConfig string, ignored []string) *Repo
 	if lint && !slices.Contains(ignored, RPMLINT_CHECK_ID) {
 		alerts := Lint(s, linterConfig)
 		appendLinterAlerts(report, alerts)
+		report.Checks = append(report.Checks, RPMLINT_CHECK_ID)
 	}
 
 	for id, checker := range checkers {
-		alerts := checker(id, s)
+		appendCheckerAlerts(report, runChecker(id, checker, s), ignored)
+		report.addCheck(id)
+	}
+
+	for _, rule := range rules {
+		appendCheckerAlerts(report, runChecker(rule.ID, rule.Check, s), ignored)
+		report.addCheck(rule.ID)
+	}
 
-		if len(alerts) == 0 {
+	for _, pack := range getRulePacks() {
+		if !pack.IsActive(s) {
 			continue
 		}
 
-		ignore := slices.Contains(ignored, id)
-
-		for _, alert := range alerts {
-			if ignore || alert.Line.Ignore {
-				alert.IsIgnored = true
-			}
-
-			switch alert.Level {
-			case LEVEL_NOTICE:
-				report.Notices = append(report.Notices, alert)
-			case LEVEL_WARNING:
-				report.Warnings = append(report.Warnings, alert)
-			case LEVEL_ERROR:
-				report.Errors = append(report.Errors, alert)
-			case LEVEL_CRITICAL:
-				report.Criticals = append(report.Criticals, alert)
-			}
+		for id, checker := range pack.Checkers {
+			appendCheckerAlerts(report, runChecker(id, checker, s), ignored)
+			report.addCheck(id)
 		}
 	}
 
+	for _, p := range plugins {
+		appendCheckerAlerts(report, runChecker(p.Name, p.Check, s), ignored)
+	}
+
 	sort.Sort(Alerts(report.Notices))
 	sort.Sort(Alerts(report.Warnings))
 	sort.Sort(Alerts(report.Errors))
 	sort.Sort(Alerts(report.Criticals))
+	sortutil.StringsNatural(report.Checks)
 
 	report.IsPerfect = report.Total()-report.Ignored() == 0
 
@@ -219,6 +267,14 @@ func Check(s *spec.Spec, lint bool, linterConfig string, ignored []string) *Repo
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// addCheck adds ID of check to the list of executed checks if check is not
+// disabled by policy
+func (r *Report) addCheck(id string) {
+	if !policy.IsDisabled(id) {
+		r.Checks = append(r.Checks, id)
+	}
+}
+
 // isApplicableTarget checks if current system is applicable for tests
 func isApplicableTarget(s *spec.Spec) bool {
 	if len(s.Targets) == 0 {
@@ -267,6 +323,74 @@ func isTargetFit(osInfo *system.OSInfo, target string) bool {
 	return false
 }
 
+// runChecker runs checker using current policy
+func runChecker(id string, checker Checker, s *spec.Spec) []Alert {
+	if policy.IsDisabled(id) {
+		return nil
+	}
+
+	var result []Alert
+
+	for _, alert := range checker(id, s) {
+		if alert.ID != id && policy.IsDisabled(alert.ID) {
+			continue
+		}
+
+		level, ok := policy.GetLevel(alert.ID)
+
+		if !ok {
+			level, ok = policy.GetLevel(id)
+		}
+
+		if ok {
+			alert.Level = level
+		}
+
+		result = append(result, alert)
+	}
+
+	return result
+}
+
+// isIgnoredCheck returns true if check with given ID, the whole group of checks
+// (e.g. PY) or all checks of plugin are ignored
+func isIgnoredCheck(ignored []string, id string) bool {
+	for _, ignoredID := range ignored {
+		switch {
+		case ignoredID == id:
+			return true
+		case ignoredID != "" && strings.HasPrefix(id, ignoredID+PLUGIN_SEPARATOR):
+			return true
+		case ignoredID != "" && !strings.ContainsAny(ignoredID, "0123456789") &&
+			strings.HasPrefix(id, ignoredID) &&
+			strings.Trim(id[len(ignoredID):], "0123456789") == "":
+			return true
+		}
+	}
+
+	return false
+}
+
+// appendCheckerAlerts appends alerts from checker to report
+func appendCheckerAlerts(r *Report, alerts []Alert, ignored []string) {
+	for _, alert := range alerts {
+		if alert.Line.Ignore || isIgnoredCheck(ignored, alert.ID) {
+			alert.IsIgnored = true
+		}
+
+		switch alert.Level {
+		case LEVEL_NOTICE:
+			r.Notices = append(r.Notices, alert)
+		case LEVEL_WARNING:
+			r.Warnings = append(r.Warnings, alert)
+		case LEVEL_ERROR:
+			r.Errors = append(r.Errors, alert)
+		case LEVEL_CRITICAL:
+			r.Criticals = append(r.Criticals, alert)
+		}
+	}
+}
+
 // appendLinterAlerts append rpmlint alerts to report
 func appendLinterAlerts(r *Report, alerts []Alert) {
 	if len(alerts) == 0 {
@@ -11,11 +11,10 @@ import (
 	"fmt"
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
+	"sync"
 
-	"github.com/essentialkaos/ek/v13/cache"
-	"github.com/essentialkaos/ek/v13/cache/memory"
-	"github.com/essentialkaos/ek/v13/req"
 	"github.com/essentialkaos/ek/v13/strutil"
 
 	"github.com/essentialkaos/perfecto/spec"
@@ -33,8 +32,6 @@ type macro struct {
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-var httpCheckCache cache.Cache
-
 var pathMacroSlice = []macro{
 	{"/etc/init", "%{_initddir}"},
 	{"/etc/rc.d/init.d", "%{_initddir}"},
@@ -58,6 +55,37 @@ var pathMacroSlice = []macro{
 	{"/var", "%{_var}"},
 }
 
+// archiveExtensions contains extensions of source archives
+var archiveExtensions = []string{
+	".tar.gz", ".tar.bz2", ".tar.xz", ".tar.zst", ".tar.lz",
+	".tgz", ".tbz2", ".txz", ".tar", ".zip", ".crate",
+}
+
+// networkCommands contains commands (binary, subcommand and flag which
+// disables network access) which require network access. Subcommand can
+// contain several words.
+var networkCommands = [][3]string{
+	{"cargo", "fetch", "--offline"},
+	{"cargo", "install", "--offline"},
+	{"curl", "", ""},
+	{"gem", "install", "--local"},
+	{"git", "clone", ""},
+	{"git", "fetch", ""},
+	{"git", "pull", ""},
+	{"go", "get", ""},
+	{"go", "mod download", ""},
+	{"go", "mod tidy", ""},
+	{"go", "mod vendor", ""},
+	{"npm", "ci", "--offline"},
+	{"npm", "install", "--offline"},
+	{"pip", "download", "--no-index"},
+	{"pip", "install", "--no-index"},
+	{"pip3", "download", "--no-index"},
+	{"pip3", "install", "--no-index"},
+	{"wget", "", ""},
+	{"yarn", "install", "--offline"},
+}
+
 var binariesAsMacro = []string{
 	"7zip", "bzip2", "bzr", "cat", "chgrp", "chmod", "chown", "cp", "cpio",
 	"file", "git", "grep", "gzip", "hg", "id", "install", "ld", "lrzip", "lzip",
@@ -69,6 +97,32 @@ var emptyLine = spec.Line{-1, "", false}
 
 var macroRegExp = regexp.MustCompile(`\%\{?\??([a-zA-Z0-9_\?\:]+)\}?`)
 
+var unitDirRegExp = regexp.MustCompile(`%\{?_(user)?unitdir\}?`)
+
+var unitFileRegExp = regexp.MustCompile(`\.(service|socket|timer)\b`)
+
+var userAddRegExp = regexp.MustCompile(`(?:^|[\s/;|&{])(?:__)?(useradd|groupadd)\b`)
+
+var sharedLibRegExp = regexp.MustCompile(`%\{?_libdir\}?/lib[^\s/]*\.so\.`)
+
+var devLinkRegExp = regexp.MustCompile(`%\{?_libdir\}?/lib[^\s/]*\.so$`)
+
+var includeDirRegExp = regexp.MustCompile(`%\{?_includedir\}?`)
+
+var negatedCondRegExp = regexp.MustCompile(`!\s*%\{(with|without) ([a-zA-Z0-9_]+)\}`)
+
+// ldconfigObsoleteSince contains minimal versions of distributions where
+// ldconfig is executed by glibc file triggers
+var ldconfigObsoleteSince = map[string]int{
+	"almalinux": 8,
+	"centos":    8,
+	"el":        8,
+	"fedora":    28,
+	"ol":        8,
+	"rhel":      8,
+	"rocky":     8,
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // getCheckers return slice with all supported checkers
@@ -102,6 +156,17 @@ func getCheckers() map[string]Checker {
 		"PF26": checkForChownAndChmod,
 		"PF27": checkForUnclosedCondition,
 		"PF28": checkForLongSummary,
+		"PF29": checkForSPDXLicense,
+		"PF30": checkForSystemdScriptlets,
+		"PF31": checkForUserCreation,
+		"PF32": checkScriptletsArgument,
+		"PF33": checkShellSyntax,
+		"PF34": checkForSharedLibraries,
+		"PF35": checkForMacroUsage,
+		"PF36": checkBuildConditions,
+		"PF37": checkForDeprecatedSyntax,
+		"PF38": checkForNetworkAccess,
+		"PF39": checkSourceURLs,
 	}
 }
 
@@ -119,12 +184,14 @@ func checkForUselessSpaces(id string, s *spec.Spec) []Alert {
 		if contains(line, " ") {
 			if strings.TrimSpace(line.Text) == "" {
 				impLine := spec.Line{line.Index, strings.ReplaceAll(line.Text, " ", "░"), line.Ignore}
-				result = append(result, NewAlert(id, LEVEL_NOTICE, "Line contains useless spaces", impLine))
+				result = append(result, NewAlert(id, LEVEL_NOTICE, "Line contains useless spaces", impLine).
+					WithSpan(0, len(impLine.Text)))
 			} else if strings.TrimRight(line.Text, " ") != line.Text {
 				cleanLine := strings.TrimRight(line.Text, " ")
 				spaces := len(line.Text) - len(cleanLine)
 				impLine := spec.Line{line.Index, cleanLine + strings.Repeat("░", spaces), line.Ignore}
-				result = append(result, NewAlert(id, LEVEL_NOTICE, "Line contains spaces at the end of line", impLine))
+				result = append(result, NewAlert(id, LEVEL_NOTICE, "Line contains spaces at the end of line", impLine).
+					WithSpan(len(cleanLine), len(impLine.Text)))
 			}
 		}
 	}
@@ -132,7 +199,7 @@ func checkForUselessSpaces(id string, s *spec.Spec) []Alert {
 	return result
 }
 
-// checkForLineLength checks changelog and description lines for 80 symbols limit
+// checkForLineLength checks changelog and description lines for line length limit
 func checkForLineLength(id string, s *spec.Spec) []Alert {
 	if len(s.Data) == 0 {
 		return nil
@@ -140,6 +207,8 @@ func checkForLineLength(id string, s *spec.Spec) []Alert {
 
 	var result []Alert
 
+	maxLength := policy.GetInt(id, "max_length")
+
 	sections := []string{
 		spec.SECTION_DESCRIPTION,
 		spec.SECTION_CHANGELOG,
@@ -156,8 +225,9 @@ func checkForLineLength(id string, s *spec.Spec) []Alert {
 				continue
 			}
 
-			if strutil.Len(line.Text) > 80 {
-				result = append(result, NewAlert(id, LEVEL_WARNING, "Line is longer than 80 symbols", line))
+			if strutil.Len(line.Text) > maxLength {
+				desc := fmt.Sprintf("Line is longer than %d symbols", maxLength)
+				result = append(result, NewAlert(id, LEVEL_WARNING, desc, line))
 			}
 		}
 	}
@@ -237,10 +307,11 @@ func checkForNonMacroPaths(id string, s *spec.Spec) []Alert {
 
 			text := line.Text
 
-			for _, macro := range pathMacroSlice {
-				re := regexp.MustCompile(macro.Value + `(\/|$|%)`)
-				if re.MatchString(text) {
-					result = append(result, NewAlert(id, LEVEL_WARNING, fmt.Sprintf("Path \"%s\" should be used as macro \"%s\"", macro.Value, macro.Name), line))
+			for _, macro := range policy.GetPairs(id, "paths") {
+				re := regexp.MustCompile(regexp.QuoteMeta(macro[0]) + `(\/|$|%)`)
+				if loc := re.FindStringIndex(text); loc != nil {
+					result = append(result, NewAlert(id, LEVEL_WARNING, fmt.Sprintf("Path \"%s\" should be used as macro \"%s\"", macro[0], macro[1]), line).
+						WithSpan(loc[0], loc[0]+len(macro[0])))
 				}
 			}
 		}
@@ -396,6 +467,13 @@ func checkForMakeMacro(id string, s *spec.Spec) []Alert {
 		spec.SECTION_CHECK,
 	}
 
+	smpFlagsInfo := "Don't forget to use %{?_smp_mflags} macro with make command"
+
+	// Don't suggest macro which is reported as obsolete by PF37
+	if policy.HasDeprecation("smp-mflags-macro") {
+		smpFlagsInfo = "Use %{make_build} macro instead of \"make\""
+	}
+
 	for _, section := range s.GetSections(sections...) {
 		for _, line := range section.Data {
 			if isComment(line) {
@@ -419,7 +497,7 @@ func checkForMakeMacro(id string, s *spec.Spec) []Alert {
 			if section.Name == spec.SECTION_BUILD && !contains(line, "%{?_smp_mflags}") {
 				if prefix(line, "make") || prefix(line, "%{__make}") {
 					if line.Text == "make" || line.Text == "%{__make}" || containsField(line, "all") {
-						result = append(result, NewAlert(id, LEVEL_WARNING, "Don't forget to use %{?_smp_mflags} macro with make command", line))
+						result = append(result, NewAlert(id, LEVEL_WARNING, smpFlagsInfo, line))
 					}
 				}
 			}
@@ -437,6 +515,8 @@ func checkForHeaderTags(id string, s *spec.Spec) []Alert {
 
 	var result []Alert
 
+	requireGroup := policy.GetBool(id, "require_group")
+
 	for _, header := range s.GetHeaders() {
 		if header.Package == "" {
 			if !containsTag(header.Data, "URL:") {
@@ -444,7 +524,7 @@ func checkForHeaderTags(id string, s *spec.Spec) []Alert {
 			}
 		}
 
-		if !containsTag(header.Data, "Group:") {
+		if requireGroup && !containsTag(header.Data, "Group:") {
 			if header.Package == "" {
 				result = append(result, NewAlert(id, LEVEL_WARNING, "Main package must contain Group tag", emptyLine))
 			} else {
@@ -472,9 +552,15 @@ func checkForUnescapedPercent(id string, s *spec.Spec) []Alert {
 				continue
 			}
 
+			var offset int
+
 			for _, word := range strings.Fields(line.Text) {
+				pos := offset + strings.Index(line.Text[offset:], word)
+				offset = pos + len(word)
+
 				if strings.HasPrefix(word, "%") && !strings.HasPrefix(word, "%%") {
-					result = append(result, NewAlert(id, LEVEL_ERROR, "Symbol % must be escaped by another % (i.e % → %%)", line))
+					result = append(result, NewAlert(id, LEVEL_ERROR, "Symbol % must be escaped by another % (i.e % → %%)", line).
+						WithSpan(pos, pos+1))
 				}
 			}
 		}
@@ -519,9 +605,12 @@ func checkForSeparatorLength(id string, s *spec.Spec) []Alert {
 
 	var result []Alert
 
+	length := policy.GetInt(id, "length")
+
 	for _, line := range s.Data {
-		if contains(line, "#") && strings.Trim(line.Text, "#") == "" && strings.Count(line.Text, "#") != 80 {
-			result = append(result, NewAlert(id, LEVEL_NOTICE, "Separator must be 80 symbols long", line))
+		if contains(line, "#") && strings.Trim(line.Text, "#") == "" && strings.Count(line.Text, "#") != length {
+			desc := fmt.Sprintf("Separator must be %d symbols long", length)
+			result = append(result, NewAlert(id, LEVEL_NOTICE, desc, line))
 		}
 	}
 
@@ -571,7 +660,7 @@ func checkForUselessBinaryMacro(id string, s *spec.Spec) []Alert {
 	var result []Alert
 
 	for _, line := range s.Data {
-		for _, binary := range binariesAsMacro {
+		for _, binary := range policy.GetStrings(id, "binaries") {
 			if contains(line, "%{__"+binary+"}") {
 				result = append(result, NewAlert(id, LEVEL_NOTICE, fmt.Sprintf("Useless macro %%{__%s} used for executing %s binary", binary, binary), line))
 			}
@@ -733,10 +822,8 @@ func checkURLForHTTPS(id string, s *spec.Spec) []Alert {
 		return nil
 	}
 
-	if httpCheckCache == nil {
-		httpCheckCache, _ = memory.New(memory.Config{
-			DefaultExpiration: cache.HOUR,
-		})
+	if urlProber == nil {
+		return nil
 	}
 
 	var result []Alert
@@ -751,6 +838,10 @@ func checkURLForHTTPS(id string, s *spec.Spec) []Alert {
 		}
 	}
 
+	var domains []string
+
+	lineDomains := make(map[int]string)
+
 	for _, line := range urls {
 		lineText := strings.TrimLeft(line.Text, "\t ")
 		url := strutil.ReadField(lineText, 1, true, ' ')
@@ -765,13 +856,29 @@ func checkURLForHTTPS(id string, s *spec.Spec) []Alert {
 			continue
 		}
 
-		if isHostSupportsHTTPS(domain) {
-			result = append(result, NewAlert(
-				id, LEVEL_WARNING,
-				fmt.Sprintf("Domain %s supports HTTPS. Replace http by https in URL.", domain),
-				line,
-			))
+		if !slices.Contains(domains, domain) {
+			domains = append(domains, domain)
+		}
+
+		lineDomains[line.Index] = domain
+	}
+
+	supported := probeHTTPSSupport(domains)
+
+	for _, line := range urls {
+		domain := lineDomains[line.Index]
+
+		if domain == "" || !supported[domain] {
+			continue
 		}
+
+		pos := strings.Index(line.Text, "http://")
+
+		result = append(result, NewAlert(
+			id, LEVEL_WARNING,
+			fmt.Sprintf("Domain %s supports HTTPS. Replace http by https in URL.", domain),
+			line,
+		).WithSpan(pos, pos+strings.IndexAny(line.Text[pos:]+" ", " \t")))
 	}
 
 	return result
@@ -783,6 +890,8 @@ func checkForCheckMacro(id string, s *spec.Spec) []Alert {
 		return nil
 	}
 
+	s = getOriginSpec(s)
+
 	if !s.HasSection(spec.SECTION_CHECK) {
 		return nil
 	}
@@ -796,6 +905,10 @@ func checkForCheckMacro(id string, s *spec.Spec) []Alert {
 			if contains(line, "?_without_check") && contains(line, "?_with_check") {
 				return nil
 			}
+
+			if prefix(line, "%if") && (contains(line, "%{with ") || contains(line, "%{without ")) {
+				return nil
+			}
 		}
 	}
 
@@ -928,14 +1041,14 @@ func checkForEmptyIf(id string, s *spec.Spec) []Alert {
 				continue
 			}
 
-			if !macroOpen && clauseOpen && !prefix(line, "fi") {
+			if !macroOpen && clauseOpen && !isShellKeyword(strings.TrimLeft(line.Text, "\t "), "fi") {
 				hasContent = true
 			}
 
-			if prefix(line, "fi") {
+			if isShellKeyword(strings.TrimLeft(line.Text, "\t "), "fi") {
 				if clauseOpen && !hasContent {
 					desc := fmt.Sprintf("Evaluated if clause can be empty. Change the order of clauses (i.e. %%if → if instead of if → %%if).")
-					result = append(result, NewAlert(id, LEVEL_WARNING, desc, clauseLine))
+					result = append(result, NewAlert(id, LEVEL_WARNING, desc, clauseLine).WithEndLine(line))
 				}
 
 				clauseOpen, macroOpen, hasContent = false, false, false
@@ -1048,7 +1161,7 @@ func checkForUnclosedCondition(id string, s *spec.Spec) []Alert {
 				}
 			}
 
-			if prefix(line, "fi") && len(conditions) != 0 {
+			if isShellKeyword(strings.TrimLeft(line.Text, "\t "), "fi") && len(conditions) != 0 {
 				conditions = conditions[:len(conditions)-1]
 			}
 		}
@@ -1071,6 +1184,8 @@ func checkForLongSummary(id string, s *spec.Spec) []Alert {
 
 	var result []Alert
 
+	limit := policy.GetInt(id, "limit")
+
 	for _, header := range s.GetHeaders() {
 		for _, line := range header.Data {
 			if prefix(line, "Summary:") {
@@ -1078,8 +1193,8 @@ func checkForLongSummary(id string, s *spec.Spec) []Alert {
 				summary = strings.TrimLeft(summary, " ")
 				summaryLen := strutil.LenVisual(summary)
 
-				if strutil.LenVisual(summary) >= 70 {
-					desc := fmt.Sprintf("Package summary is too long (%d ≥ 70)", summaryLen)
+				if summaryLen >= limit {
+					desc := fmt.Sprintf("Package summary is too long (%d ≥ %d)", summaryLen, limit)
 					result = append(result, NewAlert(id, LEVEL_NOTICE, desc, line))
 				}
 			}
@@ -1089,97 +1204,1275 @@ func checkForLongSummary(id string, s *spec.Spec) []Alert {
 	return result
 }
 
-// ////////////////////////////////////////////////////////////////////////////////// //
+// checkForSPDXLicense checks License tag for valid SPDX expression
+func checkForSPDXLicense(id string, s *spec.Spec) []Alert {
+	if len(s.Data) == 0 {
+		return nil
+	}
 
-// prefix is strings.HasPrefix wrapper
-func prefix(line spec.Line, value string) bool {
-	return strings.HasPrefix(strings.TrimLeft(line.Text, "\t "), value)
-}
+	var result []Alert
 
-// suffix is strings.HasSuffix wrapper
-func suffix(line spec.Line, value string) bool {
-	return strings.HasSuffix(strings.TrimLeft(line.Text, "\t "), value)
-}
+	for _, header := range s.GetHeaders() {
+		for _, line := range header.Data {
+			if isComment(line) || !prefix(line, "License:") {
+				continue
+			}
 
-// contains is strings.Contains wrapper
-func contains(line spec.Line, value string) bool {
-	return strings.Contains(line.Text, value)
-}
+			license := strings.TrimSpace(strings.TrimPrefix(strings.TrimLeft(line.Text, "\t "), "License:"))
 
-// contains checks if line contain given macro
-func containsMacro(line spec.Line, macro string) bool {
-	for _, found := range macroRegExp.FindAllStringSubmatch(line.Text, -1) {
-		if found[1] == macro {
-			return true
+			if license == "" || strings.Contains(license, "%") {
+				continue
+			}
+
+			converted, ambiguous, isLegacy := convertLegacyLicense(license)
+
+			switch {
+			case isLegacy && len(ambiguous) != 0:
+				for _, name := range ambiguous {
+					desc := fmt.Sprintf(
+						"Legacy license name \"%s\" must be replaced by one of SPDX identifiers: %s",
+						name, strings.Join(spdxLegacyNames[name], ", "),
+					)
+					result = append(result, NewAlert(id, LEVEL_WARNING, desc, line))
+				}
+				continue
+			case isLegacy:
+				desc := fmt.Sprintf("License tag contains legacy license names, use SPDX expression \"%s\" instead", converted)
+				fix := strings.Replace(line.Text, license, converted, 1)
+				result = append(result, NewAlertWithFix(id, LEVEL_WARNING, desc, line, fix))
+				continue
+			}
+
+			ids, err := parseSPDXExpression(license)
+
+			if err != nil {
+				result = append(result, NewAlert(id, LEVEL_ERROR, fmt.Sprintf("License tag contains invalid SPDX expression: %v", err), line))
+				continue
+			}
+
+			for _, licenseID := range ids {
+				if !isSPDXLicense(licenseID) {
+					result = append(result, NewAlert(id, LEVEL_WARNING, fmt.Sprintf("Unknown SPDX license identifier \"%s\"", licenseID), line))
+				}
+			}
 		}
 	}
 
-	return false
+	return result
 }
 
-// containsField return true if line contains given field
-func containsField(line spec.Line, value string) bool {
-	return slices.Contains(strutil.Fields(line.Text), value)
-}
+// checkForSystemdScriptlets checks packages with systemd units for scriptlets
+// macros and raw systemctl calls
+func checkForSystemdScriptlets(id string, s *spec.Spec) []Alert {
+	if len(s.Data) == 0 {
+		return nil
+	}
 
-// isComment return true if current line is commented
-func isComment(line spec.Line) bool {
-	return prefix(line, "#")
-}
+	var result []Alert
+	var packages []string
 
-// isEmptyData check if data is empty or contains only spaces
-func isEmptyData(data []spec.Line) bool {
-	for _, line := range data {
-		if strings.ReplaceAll(line.Text, " ", "") != "" {
-			return false
+	units := make(map[string]spec.Line)
+	userUnits := make(map[string]bool)
+
+	for _, section := range s.GetSections(spec.SECTION_FILES) {
+		for _, line := range section.Data {
+			if isComment(line) {
+				continue
+			}
+
+			found := unitDirRegExp.FindStringSubmatch(line.Text)
+
+			if found == nil {
+				continue
+			}
+
+			pkg := section.GetPackageName()
+
+			if _, ok := units[pkg]; !ok {
+				packages = append(packages, pkg)
+				units[pkg] = line
+			}
+
+			userUnits[pkg] = userUnits[pkg] || found[1] != ""
 		}
 	}
 
-	return true
-}
+	if len(units) == 0 {
+		for _, section := range s.GetSections(spec.SECTION_INSTALL) {
+			for _, line := range section.Data {
+				if isComment(line) || !unitFileRegExp.MatchString(line.Text) {
+					continue
+				}
 
-// containsArgs return true if section contains given args
-func containsArgs(section *spec.Section, args ...string) bool {
-	for _, arg := range args {
-		if !slices.Contains(section.Args, arg) {
-			return false
+				if len(packages) == 0 && (prefix(line, "install ") || prefix(line, "%{__install} ") || prefix(line, "cp ")) {
+					packages = append(packages, "")
+					units[""] = line
+				}
+			}
 		}
 	}
 
-	return true
-}
+	for _, pkg := range packages {
+		macros := [][2]string{
+			{spec.SECTION_POST, "systemd_post"},
+			{spec.SECTION_PREUN, "systemd_preun"},
+			{spec.SECTION_POSTUN, "systemd_postun_with_restart"},
+		}
 
-// containsTag check if data contains given tag
-func containsTag(data []spec.Line, tag string) bool {
-	for _, line := range data {
-		if prefix(line, tag) {
-			return true
+		if userUnits[pkg] {
+			macros = [][2]string{
+				{spec.SECTION_POST, "systemd_user_post"},
+				{spec.SECTION_PREUN, "systemd_user_preun"},
+				{spec.SECTION_POSTUN, "systemd_user_postun_with_restart"},
+			}
+		}
+
+		for _, m := range macros {
+			sections := getPackageSections(s, m[0], pkg)
+
+			if sectionsContainsMacro(sections, m[1]) {
+				continue
+			}
+
+			if m[0] == spec.SECTION_POSTUN && sectionsContainsMacro(sections, strings.TrimSuffix(m[1], "_with_restart")) {
+				desc := fmt.Sprintf("Use %%%s macro in %%postun scriptlet to restart units on upgrade", m[1])
+				result = append(result, NewAlert(id, LEVEL_WARNING, desc, units[pkg]))
+				continue
+			}
+
+			desc := fmt.Sprintf(
+				"%s contains systemd units, but %%%s scriptlet doesn't contain %%%s macro",
+				formatPackageName(pkg), m[0], m[1],
+			)
+
+			result = append(result, NewAlert(id, LEVEL_ERROR, desc, units[pkg]))
 		}
 	}
 
-	return false
+	if len(packages) != 0 && !hasBuildRequires(s, "systemd-rpm-macros", "systemd") {
+		result = append(result, NewAlert(id, LEVEL_WARNING, "Spec contains systemd units, but systemd-rpm-macros is not listed in BuildRequires", emptyLine))
+	}
+
+	sections := []string{
+		spec.SECTION_POST,
+		spec.SECTION_POSTTRANS,
+		spec.SECTION_POSTUN,
+		spec.SECTION_PRE,
+		spec.SECTION_PRETRANS,
+		spec.SECTION_PREUN,
+		spec.SECTION_TRIGGERIN,
+		spec.SECTION_TRIGGERPOSTUN,
+		spec.SECTION_TRIGGERUN,
+	}
+
+	for _, section := range s.GetSections(sections...) {
+		for _, line := range section.Data {
+			if isComment(line) || !contains(line, "systemctl") {
+				continue
+			}
+
+			switch {
+			case containsField(line, "enable"):
+				result = append(result, NewAlert(id, LEVEL_WARNING, "Use %systemd_post macro instead of \"systemctl enable\" in scriptlets", line))
+			case containsField(line, "daemon-reload"):
+				result = append(result, NewAlert(id, LEVEL_WARNING, "Don't use \"systemctl daemon-reload\" in scriptlets, %systemd_* macros reload systemd configuration automatically", line))
+			}
+		}
+	}
+
+	return result
 }
 
-// extractDomainFromURL extracts domain name from source URL
-func extractDomainFromURL(url string) string {
-	url = strutil.Exclude(url, "http://")
-	return strutil.ReadField(url, 0, false, '/')
+// checkForUserCreation checks %pre scriptlets for imperative user and group creation
+func checkForUserCreation(id string, s *spec.Spec) []Alert {
+	if len(s.Data) == 0 {
+		return nil
+	}
+
+	var result []Alert
+
+	for _, section := range s.GetSections(spec.SECTION_PRE) {
+		var commands []spec.Line
+		var hasExit, hasSysusers bool
+
+		for _, line := range section.Data {
+			if isComment(line) {
+				continue
+			}
+
+			switch {
+			case containsMacro(line, "sysusers_create_compat"):
+				hasSysusers = true
+			case userAddRegExp.MatchString(line.Text):
+				commands = append(commands, line)
+			case prefix(line, "exit 0"):
+				hasExit = true
+			}
+		}
+
+		if len(commands) == 0 {
+			continue
+		}
+
+		if !hasSysusers {
+			result = append(result, NewAlert(id, LEVEL_NOTICE, "Use sysusers.d file with %sysusers_create_compat macro instead of useradd/groupadd", commands[0]))
+		}
+
+		pkg := section.GetPackageName()
+
+		if !hasPackageRequires(s, pkg, "Requires(pre):", "shadow-utils") {
+			desc := fmt.Sprintf("%s creates users or groups in %%pre scriptlet, but doesn't require shadow-utils (Requires(pre): shadow-utils)", formatPackageName(pkg))
+			result = append(result, NewAlert(id, LEVEL_WARNING, desc, commands[0]))
+		}
+
+		for _, line := range commands {
+			command := userAddRegExp.FindStringSubmatch(line.Text)[1]
+
+			if !contains(line, "getent") && !contains(s.GetLine(line.Index-1), "getent") {
+				desc := fmt.Sprintf("Check if user or group already exists using getent before calling %s", command)
+				result = append(result, NewAlert(id, LEVEL_WARNING, desc, line))
+			}
+
+			if !hasExit && !contains(line, "|| :") && !contains(line, "|| true") {
+				desc := fmt.Sprintf("Failed %s call will abort transaction, add \"|| :\" to the end of command", command)
+				result = append(result, NewAlert(id, LEVEL_WARNING, desc, line))
+			}
+		}
+	}
+
+	return result
 }
 
-// isHostSupportsHTTPS return true if domain supports HTTPS protocol
-func isHostSupportsHTTPS(domain string) bool {
-	if httpCheckCache.Has(domain) {
-		return httpCheckCache.Get(domain).(bool)
+// checkScriptletsArgument checks scriptlets for destructive commands executed
+// without checking $1 and for commands which can fail the transaction
+func checkScriptletsArgument(id string, s *spec.Spec) []Alert {
+	if len(s.Data) == 0 {
+		return nil
+	}
+
+	var result []Alert
+
+	sections := []string{
+		spec.SECTION_POST,
+		spec.SECTION_POSTUN,
+		spec.SECTION_PRE,
+		spec.SECTION_PREUN,
+	}
+
+	for _, section := range s.GetSections(sections...) {
+		if slices.Contains(section.Args, "-p") {
+			continue
+		}
+
+		var blocks []bool
+		var lastLine spec.Line
+
+		for _, line := range section.Data {
+			text := strings.TrimLeft(line.Text, "\t ")
+
+			if text == "" || isComment(line) || (prefix(line, "%") && !prefix(line, "%{__")) {
+				continue
+			}
+
+			lastLine = line
+			hasArg := hasScriptletArg(text)
+			isCondition := prefix(line, "if ") || prefix(line, "case ")
+			isOneLine := hasShellKeywordSuffix(text, "fi") || hasShellKeywordSuffix(text, "esac")
+
+			switch {
+			case isCondition && !isOneLine:
+				blocks = append(blocks, hasArg)
+				continue
+			case isCondition && hasScriptletArg(getShellCondition(text)):
+				// One-line condition which checks $1 guards the rest of the line
+				continue
+			case (isShellKeyword(text, "fi") || isShellKeyword(text, "esac")) && len(blocks) != 0:
+				blocks = blocks[:len(blocks)-1]
+				continue
+			}
+
+			command := getDestructiveCommand(line)
+
+			if command == "" || slices.Contains(blocks, true) {
+				continue
+			}
+
+			if hasArg && (strings.Contains(text, "&&") || strings.Contains(text, "||")) {
+				continue
+			}
+
+			desc := fmt.Sprintf("Destructive command \"%s\" executed in %%%s scriptlet without checking $1 to distinguish erase from upgrade", command, section.Name)
+			result = append(result, NewAlert(id, LEVEL_ERROR, desc, line))
+		}
+
+		if lastLine.Text == "" || !canCommandFail(lastLine) {
+			continue
+		}
+
+		desc := fmt.Sprintf("The last command in %%%s scriptlet can fail and abort transaction, add \"|| :\" to the end of it", section.Name)
+		result = append(result, NewAlert(id, LEVEL_WARNING, desc, lastLine))
 	}
 
-	_, err := req.Request{
-		URL:         "https://" + domain,
-		AutoDiscard: true,
-	}.Head()
+	return result
+}
 
-	supported := err == nil
+// checkShellSyntax checks build sections and scriptlets for shell syntax errors
+func checkShellSyntax(id string, s *spec.Spec) []Alert {
+	if len(s.Data) == 0 {
+		return nil
+	}
 
-	httpCheckCache.Set(domain, supported)
+	var result []Alert
 
-	return supported
+	sections := []string{
+		spec.SECTION_BUILD,
+		spec.SECTION_CHECK,
+		spec.SECTION_INSTALL,
+		spec.SECTION_POST,
+		spec.SECTION_POSTTRANS,
+		spec.SECTION_POSTUN,
+		spec.SECTION_PRE,
+		spec.SECTION_PREP,
+		spec.SECTION_PRETRANS,
+		spec.SECTION_PREUN,
+		spec.SECTION_TRIGGERIN,
+		spec.SECTION_TRIGGERPOSTUN,
+		spec.SECTION_TRIGGERUN,
+		spec.SECTION_VERIFYSCRIPT,
+	}
+
+	for _, section := range s.GetSections(sections...) {
+		if !isShellSection(section) {
+			continue
+		}
+
+		shellErr := parseShellScript(section.Data)
+
+		if shellErr == nil {
+			continue
+		}
+
+		desc := fmt.Sprintf("Shell syntax error in %%%s section: %s", section.Name, shellErr.Text)
+		result = append(result, NewAlert(id, LEVEL_CRITICAL, desc, shellErr.Line))
+	}
+
+	return result
+}
+
+// checkForSharedLibraries checks packages with shared libraries for ldconfig
+// scriptlets and -devel subpackages for development files
+func checkForSharedLibraries(id string, s *spec.Spec) []Alert {
+	if len(s.Data) == 0 {
+		return nil
+	}
+
+	var result []Alert
+	var libPackages []string
+
+	libs := make(map[string]spec.Line)
+
+	for _, section := range s.GetSections(spec.SECTION_FILES) {
+		for _, line := range section.Data {
+			if isComment(line) || !sharedLibRegExp.MatchString(line.Text) {
+				continue
+			}
+
+			pkg := section.GetPackageName()
+
+			if _, ok := libs[pkg]; !ok {
+				libPackages = append(libPackages, pkg)
+				libs[pkg] = line
+			}
+		}
+	}
+
+	if len(libPackages) == 0 {
+		return nil
+	}
+
+	isObsolete := isLDConfigObsolete(s)
+
+	for _, pkg := range libPackages {
+		lines, hasPost, hasPostun := findLDConfigCalls(s, pkg)
+
+		if isObsolete {
+			for _, line := range lines {
+				result = append(result, NewAlert(id, LEVEL_NOTICE, "ldconfig scriptlets are obsolete on target systems, glibc runs ldconfig using file triggers", line))
+			}
+
+			continue
+		}
+
+		if !hasPost {
+			desc := fmt.Sprintf("%s contains shared libraries, but %%post scriptlet doesn't run ldconfig (use %%ldconfig_scriptlets macro)", formatPackageName(pkg))
+			result = append(result, NewAlert(id, LEVEL_WARNING, desc, libs[pkg]))
+		}
+
+		if !hasPostun {
+			desc := fmt.Sprintf("%s contains shared libraries, but %%postun scriptlet doesn't run ldconfig (use %%ldconfig_scriptlets macro)", formatPackageName(pkg))
+			result = append(result, NewAlert(id, LEVEL_WARNING, desc, libs[pkg]))
+		}
+	}
+
+	for _, section := range s.GetSections(spec.SECTION_FILES) {
+		if isDevelPackage(section.GetPackageName()) {
+			continue
+		}
+
+		for _, line := range section.Data {
+			if isComment(line) {
+				continue
+			}
+
+			switch {
+			case devLinkRegExp.MatchString(strings.TrimRight(line.Text, " \t")):
+				result = append(result, NewAlert(id, LEVEL_WARNING, "Unversioned shared library symlink must be packaged in -devel subpackage", line))
+			case includeDirRegExp.MatchString(line.Text):
+				result = append(result, NewAlert(id, LEVEL_WARNING, "Header files must be packaged in -devel subpackage", line))
+			}
+		}
+	}
+
+	for _, header := range s.GetHeaders() {
+		if !isDevelPackage(header.Package) || len(header.Data) == 0 {
+			continue
+		}
+
+		var hasRequires bool
+
+		for _, line := range header.Data {
+			if isComment(line) || !prefix(line, "Requires:") {
+				continue
+			}
+
+			for _, field := range strutil.Fields(strings.ReplaceAll(line.Text, "\t", " ")) {
+				if !strings.HasPrefix(field, "%{name}") && !strings.HasPrefix(field, "%name") {
+					continue
+				}
+
+				hasRequires = true
+
+				if !strings.Contains(field, "%{?_isa}") {
+					fix := strings.Replace(line.Text, field, field+"%{?_isa}", 1)
+					result = append(result, NewAlertWithFix(id, LEVEL_WARNING, "Use %{?_isa} in requirement of base package to match its architecture", line, fix))
+				}
+			}
+		}
+
+		if !hasRequires {
+			desc := fmt.Sprintf("%s doesn't require base package (Requires: %%{name}%%{?_isa} = %%{version}-%%{release})", formatPackageName(header.Package))
+			result = append(result, NewAlert(id, LEVEL_WARNING, desc, header.Data[0]))
+		}
+	}
+
+	return result
+}
+
+// checkForMacroUsage checks for undefined, unused and redefined macros
+func checkForMacroUsage(id string, s *spec.Spec) []Alert {
+	if len(s.Data) == 0 {
+		return nil
+	}
+
+	var result []Alert
+
+	s = getOriginSpec(s)
+	table := buildMacroTable(s)
+	reported := make(map[string]bool)
+
+	for i, def := range table.Defs {
+		name := fmt.Sprintf("Macro %%%s", def.Name)
+
+		if def.IsBcond {
+			name = fmt.Sprintf("Build condition %q", strings.TrimPrefix(def.Name, "with_"))
+		}
+
+		if def.IsDefine && def.IsTopLevel {
+			fix := strings.Replace(def.Line.Text, "%define", "%global", 1)
+			result = append(result, NewAlertWithFix(id, LEVEL_NOTICE, "Use %global instead of %define for macros defined at top level", def.Line, fix))
+		}
+
+		for _, prev := range table.Defs[:i] {
+			if prev.Name == def.Name && prev.Branch == def.Branch {
+				desc := fmt.Sprintf("%s is already defined on line %d", name, prev.Line.Index)
+				result = append(result, NewAlert(id, LEVEL_WARNING, desc, def.Line))
+				break
+			}
+		}
+
+		if def.IsBcond || reported[def.Name] || strings.HasPrefix(def.Name, "_") ||
+			isBuiltinMacro(def.Name) || table.IsUsed(def.Name) {
+			continue
+		}
+
+		reported[def.Name] = true
+		result = append(result, NewAlert(id, LEVEL_WARNING, "Macro %"+def.Name+" is defined but never used", def.Line))
+	}
+
+	for _, usage := range table.Usages {
+		if usage.IsOptional || reported[usage.Name] ||
+			isBuiltinMacro(usage.Name) || table.IsDefined(usage.Name) {
+			continue
+		}
+
+		reported[usage.Name] = true
+		// List of known macros can't be complete, so we can't be sure
+		// that macro is really undefined
+		desc := fmt.Sprintf("Macro %%%s is used but never defined", usage.Name)
+		result = append(result, NewAlert(id, LEVEL_WARNING, desc, usage.Line))
+	}
+
+	return result
+}
+
+// checkBuildConditions checks declarations and tests of build conditions (%bcond)
+func checkBuildConditions(id string, s *spec.Spec) []Alert {
+	if len(s.Data) == 0 {
+		return nil
+	}
+
+	var result []Alert
+
+	s = getOriginSpec(s)
+	table := buildMacroTable(s)
+	declared := make(map[string]bool)
+
+	for _, cond := range s.GetConditions() {
+		declared[cond.Name] = true
+
+		if len(strutil.Fields(cond.Line.Text)) < 3 && !cond.HasDefault {
+			desc := fmt.Sprintf("Build condition %q must have default value (%%bcond name 0|1)", cond.Name)
+			result = append(result, NewAlert(id, LEVEL_ERROR, desc, cond.Line))
+		}
+
+		if cond.Name != "" && !table.IsUsed("with_"+cond.Name) {
+			desc := fmt.Sprintf("Build condition %q is declared but never tested", cond.Name)
+			result = append(result, NewAlert(id, LEVEL_WARNING, desc, cond.Line))
+		}
+	}
+
+	reported := make(map[string]bool)
+
+	for _, usage := range table.Usages {
+		if !strings.HasPrefix(usage.Name, "with_") {
+			continue
+		}
+
+		name := strings.TrimPrefix(usage.Name, "with_")
+
+		switch {
+		case usage.IsBcondTest && !declared[name] && !reported[name]:
+			reported[name] = true
+			desc := fmt.Sprintf("Build condition %q is tested but never declared with %%bcond", name)
+			result = append(result, NewAlert(id, LEVEL_ERROR, desc, usage.Line))
+		case usage.IsBcondTest || !declared[name]:
+			continue
+		case usage.Raw == usage.Name:
+			if !usage.IsOptional {
+				desc := fmt.Sprintf("Macro %%%s is undefined if build condition is disabled, use %%{with %s} instead", usage.Raw, name)
+				result = append(result, NewAlert(id, LEVEL_ERROR, desc, usage.Line))
+			}
+		case !reported[usage.Raw]:
+			reported[usage.Raw] = true
+			desc := fmt.Sprintf("Use %%{with %s} or %%{without %s} instead of %%%s, it is defined only if condition is set from command line", name, name, usage.Raw)
+			result = append(result, NewAlert(id, LEVEL_WARNING, desc, usage.Line))
+		}
+	}
+
+	for _, line := range s.Data {
+		if isComment(line) || !prefix(line, "%if") && !prefix(line, "%elif") {
+			continue
+		}
+
+		for _, found := range negatedCondRegExp.FindAllStringSubmatch(line.Text, -1) {
+			opposite := "without"
+
+			if found[1] == "without" {
+				opposite = "with"
+			}
+
+			desc := fmt.Sprintf("Use %%{%s %s} instead of negated %%{%s %s}", opposite, found[2], found[1], found[2])
+			fix := strings.Replace(line.Text, found[0], "%{"+opposite+" "+found[2]+"}", 1)
+			result = append(result, NewAlertWithFix(id, LEVEL_NOTICE, desc, line, fix))
+		}
+	}
+
+	return result
+}
+
+// checkForDeprecatedSyntax checks spec for obsolete constructs from catalog
+func checkForDeprecatedSyntax(id string, s *spec.Spec) []Alert {
+	if len(s.Data) == 0 {
+		return nil
+	}
+
+	var result []Alert
+
+	skipLines := make(map[int]bool)
+
+	for _, section := range s.GetSections(spec.SECTION_CHANGELOG) {
+		for _, line := range section.Data {
+			skipLines[line.Index] = true
+		}
+	}
+
+	for _, d := range deprecations {
+		if d.IsOptional && !policy.HasDeprecation(d.Name) {
+			continue
+		}
+
+		level, ok := policy.GetLevel(d.Name)
+
+		if !ok {
+			level = d.Level
+		}
+
+		for _, line := range d.Find(s, skipLines) {
+			desc := fmt.Sprintf("%s is obsolete since %s, %s", d.Construct, d.Since, d.Replacement)
+			result = append(result, NewAlert(id, level, desc, line))
+		}
+	}
+
+	return result
+}
+
+// checkForNetworkAccess checks build sections for commands which require
+// network access
+func checkForNetworkAccess(id string, s *spec.Spec) []Alert {
+	if len(s.Data) == 0 {
+		return nil
+	}
+
+	var result []Alert
+
+	sections := []string{
+		spec.SECTION_BUILD,
+		spec.SECTION_CHECK,
+		spec.SECTION_INSTALL,
+		spec.SECTION_PREP,
+		spec.SECTION_SETUP,
+	}
+
+	for _, section := range s.GetSections(sections...) {
+		for _, line := range section.Data {
+			if isComment(line) {
+				continue
+			}
+
+			command, url := getNetworkCommand(line)
+
+			switch {
+			case command == "":
+				continue
+			case url != "":
+				name := section.Name
+
+				if name == spec.SECTION_SETUP {
+					name = spec.SECTION_PREP
+				}
+
+				desc := fmt.Sprintf("Don't download %s in %%%s section, declare it as Source instead", url, name)
+				result = append(result, NewAlert(id, LEVEL_ERROR, desc, line))
+			default:
+				desc := fmt.Sprintf("Command \"%s\" requires network access which is not available on build systems (Koji, mock)", command)
+				result = append(result, NewAlert(id, LEVEL_ERROR, desc, line))
+			}
+		}
+	}
+
+	return result
+}
+
+// checkSourceURLs checks source URLs for hardcoded versions, archive names and
+// canonical forms of forge URLs
+func checkSourceURLs(id string, s *spec.Spec) []Alert {
+	if len(s.Data) == 0 {
+		return nil
+	}
+
+	var result []Alert
+
+	values := s.GetMacroValues()
+	version := spec.ExpandMacros(values["version"], values)
+
+	for _, line := range s.GetSources() {
+		tag := strutil.ReadField(strings.TrimSpace(line.Text), 0, true, ' ', '\t')
+		url := strutil.ReadField(strings.TrimSpace(line.Text), 1, true, ' ', '\t')
+		expURL := spec.ExpandMacros(url, values)
+
+		if !strutil.HasPrefixAny(expURL, "http://", "https://", "ftp://") {
+			continue
+		}
+
+		// Only literal version is reported, version with unknown macros can't
+		// be found in URL
+		if len(version) >= 3 && !strings.Contains(version, "%") && strings.Contains(url, version) {
+			desc := fmt.Sprintf("Source URL contains hardcoded version %s, use %%{version} macro instead", version)
+			fix := strings.Replace(line.Text, url, strings.ReplaceAll(url, version, "%{version}"), 1)
+			result = append(result, NewAlertWithFix(id, LEVEL_WARNING, desc, line, fix))
+		}
+
+		if forge := getNonCanonicalForge(expURL); forge != "" {
+			desc := fmt.Sprintf("Use canonical %s archive URL for source", forge)
+			result = append(result, NewAlert(id, LEVEL_NOTICE, desc, line))
+		}
+
+		if tag != "Source0:" && tag != "Source:" {
+			continue
+		}
+
+		setupLine, setupDir := getSetupDir(s)
+		archiveDir := getArchiveDir(expURL)
+		setupDir = spec.ExpandMacros(setupDir, values)
+
+		if setupDir == "" || archiveDir == "" || strings.Contains(setupDir+archiveDir, "%") {
+			continue
+		}
+
+		if archiveDir != setupDir {
+			desc := fmt.Sprintf("Source archive %s doesn't match directory %s expected by %%setup (use -n option)", archiveDir, setupDir)
+			result = append(result, NewAlert(id, LEVEL_NOTICE, desc, setupLine))
+		}
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// prefix is strings.HasPrefix wrapper
+func prefix(line spec.Line, value string) bool {
+	return strings.HasPrefix(strings.TrimLeft(line.Text, "\t "), value)
+}
+
+// suffix is strings.HasSuffix wrapper
+func suffix(line spec.Line, value string) bool {
+	return strings.HasSuffix(strings.TrimLeft(line.Text, "\t "), value)
+}
+
+// contains is strings.Contains wrapper
+func contains(line spec.Line, value string) bool {
+	return strings.Contains(line.Text, value)
+}
+
+// contains checks if line contain given macro
+func containsMacro(line spec.Line, macro string) bool {
+	for _, found := range macroRegExp.FindAllStringSubmatch(line.Text, -1) {
+		if found[1] == macro {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containsField return true if line contains given field
+func containsField(line spec.Line, value string) bool {
+	return slices.Contains(strutil.Fields(line.Text), value)
+}
+
+// isComment return true if current line is commented
+func isComment(line spec.Line) bool {
+	return prefix(line, "#")
+}
+
+// isEmptyData check if data is empty or contains only spaces
+func isEmptyData(data []spec.Line) bool {
+	for _, line := range data {
+		if strings.ReplaceAll(line.Text, " ", "") != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// containsArgs return true if section contains given args
+func containsArgs(section *spec.Section, args ...string) bool {
+	for _, arg := range args {
+		if !slices.Contains(section.Args, arg) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// containsTag check if data contains given tag
+func containsTag(data []spec.Line, tag string) bool {
+	for _, line := range data {
+		if prefix(line, tag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasBuildRequires returns true if any of given packages listed in BuildRequires
+func hasBuildRequires(s *spec.Spec, packages ...string) bool {
+	for _, header := range s.GetHeaders() {
+		for _, line := range header.Data {
+			if isComment(line) || !prefix(line, "BuildRequires:") {
+				continue
+			}
+
+			for _, field := range strutil.Fields(strings.ReplaceAll(line.Text, "\t", " ")) {
+				if slices.Contains(packages, field) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// hasPackageRequires returns true if header of given package contains tag with
+// given package
+func hasPackageRequires(s *spec.Spec, pkg, tag, dep string) bool {
+	for _, header := range s.GetHeaders() {
+		if header.Package != pkg {
+			continue
+		}
+
+		for _, line := range header.Data {
+			if isComment(line) || !prefix(line, tag) {
+				continue
+			}
+
+			if slices.Contains(strutil.Fields(strings.ReplaceAll(line.Text, "\t", " ")), dep) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// getPackageSections returns sections with given name for given package
+func getPackageSections(s *spec.Spec, name, pkg string) []*spec.Section {
+	var result []*spec.Section
+
+	for _, section := range s.GetSections(name) {
+		if section.GetPackageName() == pkg {
+			result = append(result, section)
+		}
+	}
+
+	return result
+}
+
+// sectionsContainsMacro returns true if any of given sections contains macro
+func sectionsContainsMacro(sections []*spec.Section, macro string) bool {
+	for _, section := range sections {
+		for _, line := range section.Data {
+			if !isComment(line) && containsMacro(line, macro) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// getNetworkCommand returns command which requires network access and URL
+// of downloaded file
+func getNetworkCommand(line spec.Line) (string, string) {
+	fields := strutil.Fields(strings.ReplaceAll(line.Text, "\t", " "))
+
+	for i, field := range fields {
+		binary := strings.TrimSuffix(strings.TrimPrefix(field, "%{__"), "}")
+		binary = binary[strings.LastIndex(binary, "/")+1:]
+
+		for _, cmd := range networkCommands {
+			if binary != cmd[0] {
+				continue
+			}
+
+			if cmd[1] != "" && !hasSubcommand(fields[i+1:], cmd[1]) {
+				continue
+			}
+
+			if cmd[2] != "" && slices.Contains(fields, cmd[2]) {
+				continue
+			}
+
+			if cmd[1] != "" {
+				return cmd[0] + " " + cmd[1], ""
+			}
+
+			for _, arg := range fields[i+1:] {
+				if strings.HasPrefix(arg, "http://") || strings.HasPrefix(arg, "https://") ||
+					strings.HasPrefix(arg, "ftp://") {
+					return cmd[0], strings.Trim(arg, `"'`)
+				}
+			}
+
+			return cmd[0], ""
+		}
+	}
+
+	return "", ""
+}
+
+// hasSubcommand returns true if given arguments start with subcommand
+func hasSubcommand(args []string, subcommand string) bool {
+	words := strings.Fields(subcommand)
+
+	return len(args) >= len(words) && slices.Equal(args[:len(words)], words)
+}
+
+// getNonCanonicalForge returns name of forge if given URL is not canonical
+// archive URL for this forge
+func getNonCanonicalForge(url string) string {
+	host := extractDomainFromURL(strutil.Exclude(url, "https://"))
+
+	switch {
+	case host == "codeload.github.com", host == "api.github.com",
+		host == "github.com" && (strings.Contains(url, "/tarball/") || strings.Contains(url, "/zipball/")):
+		return "GitHub"
+	case host == "gitlab.com" && strings.Contains(url, "/repository/archive"):
+		return "GitLab"
+	case host == "pypi.python.org", host == "pypi.io", host == "pypi.org",
+		host == "files.pythonhosted.org" && !strings.Contains(url, "/packages/source/"):
+		return "PyPI"
+	case host == "static.crates.io",
+		host == "crates.io" && !strings.Contains(url, "/api/v1/crates/"):
+		return "crates.io"
+	}
+
+	return ""
+}
+
+// getSetupDir returns line with %setup/%autosetup macro and name of directory
+// expected by it
+func getSetupDir(s *spec.Spec) (spec.Line, string) {
+	for _, section := range s.GetSections(spec.SECTION_PREP) {
+		for _, line := range section.Data {
+			if !prefix(line, "%autosetup") {
+				continue
+			}
+
+			return line, parseSetupDir(strutil.Fields(strings.TrimSpace(line.Text))[1:])
+		}
+	}
+
+	for _, section := range s.GetSections(spec.SECTION_SETUP) {
+		return s.Data[section.Start-1], parseSetupDir(section.Args)
+	}
+
+	return emptyLine, ""
+}
+
+// parseSetupDir returns name of directory from %setup arguments
+func parseSetupDir(args []string) string {
+	dir := "%{name}-%{version}"
+
+	for i, arg := range args {
+		if !strings.HasPrefix(arg, "-") || strings.HasPrefix(arg, "--") {
+			continue
+		}
+
+		if strings.ContainsAny(arg, "cT") {
+			return ""
+		}
+
+		if strings.HasSuffix(arg, "n") && i+1 < len(args) {
+			dir = args[i+1]
+		}
+	}
+
+	return dir
+}
+
+// getArchiveDir returns name of directory which is expected in archive
+func getArchiveDir(url string) string {
+	if dir := getGithubArchiveDir(url); dir != "" {
+		return dir
+	}
+
+	file := url[strings.LastIndex(url, "/")+1:]
+	file, _, _ = strings.Cut(file, "?")
+
+	for _, ext := range archiveExtensions {
+		if strings.HasSuffix(file, ext) {
+			return strings.TrimSuffix(file, ext)
+		}
+	}
+
+	return ""
+}
+
+// getGithubArchiveDir returns name of directory in GitHub tag archive. Such
+// archives always contain directory with repository name and tag without
+// leading "v", file name in URL only names the download.
+func getGithubArchiveDir(url string) string {
+	url, _, _ = strings.Cut(url, "#")
+	url, _, _ = strings.Cut(url, "?")
+
+	if !strings.Contains(url, "github.com/") {
+		return ""
+	}
+
+	base, path, ok := strings.Cut(url, "/archive/")
+
+	if !ok {
+		return ""
+	}
+
+	path = strings.TrimPrefix(path, "refs/tags/")
+	path = strings.TrimPrefix(path, "refs/heads/")
+	tag, _, _ := strings.Cut(path, "/")
+
+	for _, ext := range archiveExtensions {
+		if strings.HasSuffix(tag, ext) {
+			tag = strings.TrimSuffix(tag, ext)
+			break
+		}
+	}
+
+	if tag == "" {
+		return ""
+	}
+
+	if len(tag) > 1 && tag[0] == 'v' && tag[1] >= '0' && tag[1] <= '9' {
+		tag = tag[1:]
+	}
+
+	return base[strings.LastIndex(base, "/")+1:] + "-" + tag
+}
+
+// getDestructiveCommand returns name of destructive command used in given line
+func getDestructiveCommand(line spec.Line) string {
+	fields := strutil.Fields(strings.ReplaceAll(line.Text, "\t", " "))
+
+	for _, field := range fields {
+		binary := strings.TrimSuffix(strings.TrimPrefix(field, "%{__"), "}")
+		binary = binary[strings.LastIndex(binary, "/")+1:]
+
+		switch {
+		case binary == "rm", binary == "userdel", binary == "groupdel":
+			return binary
+		case binary == "systemctl" && slices.Contains(fields, "stop"):
+			return "systemctl stop"
+		case binary == "semodule" && (slices.Contains(fields, "-r") || slices.Contains(fields, "--remove")):
+			return "semodule -r"
+		}
+	}
+
+	return ""
+}
+
+// canCommandFail returns true if command in given line can fail
+func canCommandFail(line spec.Line) bool {
+	text := strings.TrimSpace(line.Text)
+
+	switch text {
+	case "fi", "done", "esac", ":", "true", "exit 0", "}":
+		return false
+	}
+
+	if strings.HasSuffix(text, "\\") {
+		return false
+	}
+
+	index := strings.LastIndex(text, "||")
+
+	if index == -1 {
+		return true
+	}
+
+	switch strings.Join(strings.Fields(text[index+2:]), " ") {
+	case ":", "true", "exit 0":
+		return false
+	}
+
+	return true
+}
+
+// isShellKeyword returns true if given text is shell keyword or starts with it
+func isShellKeyword(text, keyword string) bool {
+	return text == keyword ||
+		strings.HasPrefix(text, keyword+";") ||
+		strings.HasPrefix(text, keyword+" ")
+}
+
+// hasShellKeywordSuffix returns true if given text is shell keyword or ends
+// with it
+func hasShellKeywordSuffix(text, keyword string) bool {
+	text = strings.TrimRight(text, "\t ;")
+
+	return text == keyword ||
+		strings.HasSuffix(text, ";"+keyword) ||
+		strings.HasSuffix(text, " "+keyword)
+}
+
+// getShellCondition returns condition part of if or case statement
+func getShellCondition(text string) string {
+	if strings.HasPrefix(text, "case ") {
+		cond, _, _ := strings.Cut(text, " in")
+		return cond
+	}
+
+	cond, _, _ := strings.Cut(text, "then")
+
+	return cond
+}
+
+// hasScriptletArg returns true if given text contains scriptlet argument
+func hasScriptletArg(text string) bool {
+	return strings.Contains(text, "$1") || strings.Contains(text, "${1}")
+}
+
+// isShellSection returns true if section data is shell script
+func isShellSection(section *spec.Section) bool {
+	for i, arg := range section.Args {
+		if arg == "-p" && i+1 < len(section.Args) {
+			switch section.Args[i+1] {
+			case "/bin/sh", "/bin/bash", "/usr/bin/sh", "/usr/bin/bash":
+				return true
+			}
+
+			return false
+		}
+	}
+
+	return true
+}
+
+// findLDConfigCalls returns lines with ldconfig calls for given package and
+// flags which show that ldconfig is executed in %post and %postun scriptlets
+func findLDConfigCalls(s *spec.Spec, pkg string) ([]spec.Line, bool, bool) {
+	var result []spec.Line
+	var hasPost, hasPostun bool
+
+	for _, line := range s.Data {
+		if isComment(line) || !prefix(line, "%") {
+			continue
+		}
+
+		fields := strutil.Fields(strings.ReplaceAll(line.Text, "\t", " "))
+		section := &spec.Section{Args: fields[1:]}
+
+		if section.GetPackageName() != pkg {
+			continue
+		}
+
+		switch strings.Trim(fields[0], "%{}") {
+		case "ldconfig_scriptlets":
+			hasPost, hasPostun = true, true
+		case "ldconfig_post":
+			hasPost = true
+		case "ldconfig_postun":
+			hasPostun = true
+		default:
+			continue
+		}
+
+		result = append(result, line)
+	}
+
+	for _, section := range s.GetSections(spec.SECTION_POST, spec.SECTION_POSTUN) {
+		if section.GetPackageName() != pkg {
+			continue
+		}
+
+		var found bool
+
+		if slices.Contains(section.Args, "/sbin/ldconfig") {
+			result = append(result, s.Data[section.Start-1])
+			found = true
+		}
+
+		for _, line := range section.Data {
+			if !found && !isComment(line) && (containsField(line, "/sbin/ldconfig") || containsField(line, "ldconfig")) {
+				result = append(result, line)
+				found = true
+			}
+		}
+
+		if found && section.Name == spec.SECTION_POST {
+			hasPost = true
+		} else if found {
+			hasPostun = true
+		}
+	}
+
+	return result, hasPost, hasPostun
+}
+
+// isLDConfigObsolete returns true if all spec targets run ldconfig
+// using glibc file triggers
+func isLDConfigObsolete(s *spec.Spec) bool {
+	if len(s.Targets) == 0 {
+		return false
+	}
+
+	for _, target := range s.Targets {
+		dist := strings.TrimRight(target, "0123456789")
+		version, _ := strconv.Atoi(strings.TrimPrefix(target, dist))
+		minVersion, ok := ldconfigObsoleteSince[dist]
+
+		if !ok || version < minVersion {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isDevelPackage returns true if package with given name is development package
+func isDevelPackage(pkg string) bool {
+	return pkg == "devel" || strings.HasSuffix(pkg, "-devel")
+}
+
+// getOriginSpec returns original spec if given spec is a build variant
+func getOriginSpec(s *spec.Spec) *spec.Spec {
+	if s.Origin != nil {
+		return s.Origin
+	}
+
+	return s
+}
+
+// formatPackageName returns package name for using in alert description
+func formatPackageName(pkg string) string {
+	if pkg == "" {
+		return "Main package"
+	}
+
+	return "Package " + pkg
+}
+
+// extractDomainFromURL extracts domain name from source URL
+func extractDomainFromURL(url string) string {
+	url = strutil.Exclude(url, "http://")
+	return strutil.ReadField(url, 0, false, '/')
+}
+
+// probeHTTPSSupport concurrently probes given domains for HTTPS support
+func probeHTTPSSupport(domains []string) map[string]bool {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	result := make(map[string]bool)
+
+	for _, domain := range domains {
+		wg.Add(1)
+
+		go func(domain string) {
+			defer wg.Done()
+
+			supported := urlProber.SupportsHTTPS(domain)
+
+			mu.Lock()
+			result[domain] = supported
+			mu.Unlock()
+		}(domain)
+	}
+
+	wg.Wait()
+
+	return result
 }
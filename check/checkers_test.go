@@ -9,8 +9,15 @@ package check
 
 import (
 	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/essentialkaos/ek/v13/fsutil"
 	"github.com/essentialkaos/ek/v13/system"
 
 	"github.com/essentialkaos/perfecto/spec"
@@ -41,6 +48,8 @@ func (sc *CheckSuite) TestCheckForUselessSpaces(c *chk.C) {
 	c.Assert(alerts, chk.HasLen, 2)
 	c.Assert(alerts[0].Info, chk.Equals, "Line contains spaces at the end of line")
 	c.Assert(alerts[0].Line.Text, chk.Equals, "License:            MIT░")
+	c.Assert(alerts[0].Column, chk.Equals, 24)
+	c.Assert(alerts[0].EndColumn, chk.Equals, 24)
 	c.Assert(alerts[1].Info, chk.Equals, "Line contains useless spaces")
 	c.Assert(alerts[1].Line.Index, chk.Equals, 10)
 }
@@ -51,7 +60,7 @@ func (sc *CheckSuite) TestCheckForLineLength(c *chk.C) {
 	c.Assert(err, chk.IsNil)
 	c.Assert(s, chk.NotNil)
 
-	alerts := checkForLineLength("", s)
+	alerts := checkForLineLength("PF2", s)
 
 	c.Assert(alerts, chk.HasLen, 2)
 	c.Assert(alerts[0].Info, chk.Equals, "Line is longer than 80 symbols")
@@ -78,11 +87,13 @@ func (sc *CheckSuite) TestCheckForNonMacroPaths(c *chk.C) {
 	c.Assert(err, chk.IsNil)
 	c.Assert(s, chk.NotNil)
 
-	alerts := checkForNonMacroPaths("", s)
+	alerts := checkForNonMacroPaths("PF4", s)
 
 	c.Assert(alerts, chk.HasLen, 2)
 	c.Assert(alerts[0].Info, chk.Equals, "Path \"/usr\" should be used as macro \"%{_usr}\"")
 	c.Assert(alerts[0].Line.Index, chk.Equals, 55)
+	c.Assert(alerts[0].Column, chk.Equals, 33)
+	c.Assert(alerts[0].EndColumn, chk.Equals, 36)
 	c.Assert(alerts[1].Info, chk.Equals, "Path \"/etc\" should be used as macro \"%{_sysconfdir}\"")
 	c.Assert(alerts[1].Line.Index, chk.Equals, 56)
 }
@@ -171,6 +182,16 @@ func (sc *CheckSuite) TestCheckForMakeMacro(c *chk.C) {
 	c.Assert(alerts[1].Line.Index, chk.Equals, 35)
 	c.Assert(alerts[2].Info, chk.Equals, "Use %{make_install} macro instead of \"make install\"")
 	c.Assert(alerts[2].Line.Index, chk.Equals, 40)
+
+	SetPolicy(&Policy{Deprecations: []string{"smp-mflags-macro"}})
+
+	alerts = checkForMakeMacro("", s)
+
+	c.Assert(alerts, chk.HasLen, 3)
+	c.Assert(alerts[1].Info, chk.Equals, "Use %{make_build} macro instead of \"make\"")
+	c.Assert(alerts[1].Line.Index, chk.Equals, 35)
+
+	SetPolicy(nil)
 }
 
 func (sc *CheckSuite) TestCheckForHeaderTags(c *chk.C) {
@@ -179,14 +200,14 @@ func (sc *CheckSuite) TestCheckForHeaderTags(c *chk.C) {
 	c.Assert(err, chk.IsNil)
 	c.Assert(s, chk.NotNil)
 
-	c.Assert(checkForHeaderTags("", s), chk.HasLen, 0)
+	c.Assert(checkForHeaderTags("PF9", s), chk.HasLen, 0)
 
 	s, err = spec.Read("../testdata/test_3.spec")
 
 	c.Assert(err, chk.IsNil)
 	c.Assert(s, chk.NotNil)
 
-	alerts := checkForHeaderTags("", s)
+	alerts := checkForHeaderTags("PF9", s)
 
 	c.Assert(alerts, chk.HasLen, 3)
 	c.Assert(alerts[0].Info, chk.Equals, "Main package must contain URL tag")
@@ -205,6 +226,8 @@ func (sc *CheckSuite) TestCheckForUnescapedPercent(c *chk.C) {
 	c.Assert(alerts, chk.HasLen, 1)
 	c.Assert(alerts[0].Info, chk.Equals, "Symbol % must be escaped by another % (i.e % → %%)")
 	c.Assert(alerts[0].Line.Index, chk.Equals, 67)
+	c.Assert(alerts[0].Column, chk.Equals, 18)
+	c.Assert(alerts[0].EndColumn, chk.Equals, 18)
 }
 
 func (sc *CheckSuite) TestCheckForMacroDefinitionPosition(c *chk.C) {
@@ -226,7 +249,7 @@ func (sc *CheckSuite) TestCheckForSeparatorLength(c *chk.C) {
 	c.Assert(err, chk.IsNil)
 	c.Assert(s, chk.NotNil)
 
-	alerts := checkForSeparatorLength("", s)
+	alerts := checkForSeparatorLength("PF12", s)
 
 	c.Assert(alerts, chk.HasLen, 1)
 	c.Assert(alerts[0].Info, chk.Equals, "Separator must be 80 symbols long")
@@ -254,7 +277,7 @@ func (sc *CheckSuite) TestCheckForUselessBinaryMacro(c *chk.C) {
 	c.Assert(err, chk.IsNil)
 	c.Assert(s, chk.NotNil)
 
-	alerts := checkForUselessBinaryMacro("", s)
+	alerts := checkForUselessBinaryMacro("PF14", s)
 
 	c.Assert(alerts, chk.HasLen, 1)
 	c.Assert(alerts[0].Info, chk.Equals, "Useless macro %{__rm} used for executing rm binary")
@@ -367,6 +390,20 @@ func (sc *CheckSuite) TestCheckURLForHTTPS(c *chk.C) {
 	c.Assert(err, chk.IsNil)
 	c.Assert(s, chk.NotNil)
 
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	defaultProber := urlProber
+	defer SetURLProber(defaultProber)
+
+	SetURLProber(&testProber{
+		prober: &HTTPProber{
+			TLSConfig: srv.Client().Transport.(*http.Transport).TLSClientConfig,
+			Timeout:   time.Second,
+		},
+		hosts: map[string]string{"kaos.st": strings.TrimPrefix(srv.URL, "https://")},
+	})
+
 	alerts := checkURLForHTTPS("", s)
 
 	c.Assert(alerts, chk.HasLen, 3)
@@ -374,6 +411,67 @@ func (sc *CheckSuite) TestCheckURLForHTTPS(c *chk.C) {
 	c.Assert(alerts[0].Line.Index, chk.Equals, 13)
 }
 
+func (sc *CheckSuite) TestHTTPProber(c *chk.C) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	domain := strings.TrimPrefix(srv.URL, "https://")
+	cacheFile := c.MkDir() + "/probe.json"
+
+	// Server which accepts connections but never completes TLS handshake
+	silent, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, chk.IsNil)
+	defer silent.Close()
+
+	s := &spec.Spec{Data: []spec.Line{
+		{1, "Name:               perfecto", false},
+		{2, "Source0:            http://" + domain + "/perfecto.tar.gz", false},
+		{3, "Source1:            http://127.0.0.1:1/perfecto.tar.gz", false},
+		{4, "Source2:            http://" + silent.Addr().String() + "/perfecto.tar.gz", false},
+	}}
+
+	defaultProber := urlProber
+	defer SetURLProber(defaultProber)
+
+	prober := &HTTPProber{
+		TLSConfig: srv.Client().Transport.(*http.Transport).TLSClientConfig,
+		Timeout:   100 * time.Millisecond,
+		CacheFile: cacheFile,
+	}
+
+	SetURLProber(prober)
+
+	alerts := checkURLForHTTPS("", s)
+
+	c.Assert(alerts, chk.HasLen, 1)
+	c.Assert(alerts[0].Info, chk.Equals, "Domain "+domain+" supports HTTPS. Replace http by https in URL.")
+	c.Assert(alerts[0].Line.Index, chk.Equals, 2)
+	c.Assert(alerts[0].Column, chk.Equals, 21)
+	c.Assert(alerts[0].EndColumn, chk.Equals, len(s.Data[1].Text))
+
+	c.Assert(fsutil.IsExist(cacheFile), chk.Equals, false)
+	c.Assert(prober.Save(), chk.IsNil)
+
+	data, err := os.ReadFile(cacheFile)
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(string(data), chk.Matches, `.*"127.0.0.1:1".*`)
+	c.Assert(string(data), chk.Not(chk.Matches), `.*"`+silent.Addr().String()+`".*`)
+
+	srv.Close()
+
+	SetURLProber(&HTTPProber{Offline: true, CacheFile: cacheFile})
+	c.Assert(checkURLForHTTPS("", s), chk.HasLen, 1)
+
+	SetURLProber(&HTTPProber{Offline: true, CacheFile: cacheFile + ".unknown"})
+	c.Assert(checkURLForHTTPS("", s), chk.HasLen, 0)
+
+	prober = &HTTPProber{CacheFile: cacheFile + "/probe.json"}
+	prober.SupportsHTTPS("127.0.0.1:1")
+	c.Assert(prober.Save(), chk.NotNil)
+
+	SetURLProber(nil)
+	c.Assert(checkURLForHTTPS("", s), chk.IsNil)
+}
+
 func (sc *CheckSuite) TestCheckForCheckMacro(c *chk.C) {
 	s, err := spec.Read("../testdata/test_11.spec")
 
@@ -394,6 +492,15 @@ func (sc *CheckSuite) TestCheckForCheckMacro(c *chk.C) {
 	alerts = checkForCheckMacro("", s)
 
 	c.Assert(alerts, chk.HasLen, 0)
+
+	s, err = spec.Read("../testdata/test_27.spec")
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(s, chk.NotNil)
+
+	alerts = checkForCheckMacro("", s.Variant(nil, []string{"tests"}))
+
+	c.Assert(alerts, chk.HasLen, 0)
 }
 
 func (sc *CheckSuite) TestCheckIfClause(c *chk.C) {
@@ -433,6 +540,7 @@ func (sc *CheckSuite) TestCheckForEmptyIf(c *chk.C) {
 	c.Assert(alerts, chk.HasLen, 1)
 	c.Assert(alerts[0].Info, chk.Equals, "Evaluated if clause can be empty. Change the order of clauses (i.e. %if → if instead of if → %if).")
 	c.Assert(alerts[0].Line.Index, chk.Equals, 92)
+	c.Assert(alerts[0].EndLine, chk.Equals, 96)
 }
 
 func (sc *CheckSuite) TestCheckForDotInSummary(c *chk.C) {
@@ -488,9 +596,792 @@ func (sc *CheckSuite) TestCheckForLongSummary(c *chk.C) {
 	c.Assert(err, chk.IsNil)
 	c.Assert(s, chk.NotNil)
 
-	alerts := checkForLongSummary("", s)
+	alerts := checkForLongSummary("PF28", s)
+
+	c.Assert(alerts, chk.HasLen, 1)
+}
+
+func (sc *CheckSuite) TestCheckForSPDXLicense(c *chk.C) {
+	s, err := spec.Read("../testdata/test_20.spec")
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(s, chk.NotNil)
+
+	alerts := checkForSPDXLicense("", s)
+
+	c.Assert(alerts, chk.HasLen, 4)
+	c.Assert(alerts[0].Info, chk.Equals, "License tag contains legacy license names, use SPDX expression \"GPL-2.0-or-later AND Apache-2.0\" instead")
+	c.Assert(alerts[0].Line.Index, chk.Equals, 16)
+	c.Assert(alerts[0].Fix, chk.Equals, "License:            GPL-2.0-or-later AND Apache-2.0")
+	c.Assert(alerts[1].Info, chk.Equals, "Legacy license name \"BSD\" must be replaced by one of SPDX identifiers: BSD-3-Clause, BSD-2-Clause, BSD-Source-Code")
+	c.Assert(alerts[1].Line.Index, chk.Equals, 34)
+	c.Assert(alerts[1].Fix, chk.Equals, "")
+	c.Assert(alerts[2].Info, chk.Equals, "License tag contains invalid SPDX expression: missing closing parenthesis")
+	c.Assert(alerts[2].Line.Index, chk.Equals, 45)
+	c.Assert(alerts[3].Info, chk.Equals, "Unknown SPDX license identifier \"FooBar-1.0\"")
+	c.Assert(alerts[3].Line.Index, chk.Equals, 56)
+}
+
+func (sc *CheckSuite) TestCheckForSystemdScriptlets(c *chk.C) {
+	s, err := spec.Read("../testdata/test_21.spec")
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(s, chk.NotNil)
+
+	alerts := checkForSystemdScriptlets("", s)
+
+	c.Assert(alerts, chk.HasLen, 8)
+	c.Assert(alerts[0].Info, chk.Equals, "Main package contains systemd units, but %preun scriptlet doesn't contain %systemd_preun macro")
+	c.Assert(alerts[0].Line.Index, chk.Equals, 66)
+	c.Assert(alerts[1].Info, chk.Equals, "Use %systemd_postun_with_restart macro in %postun scriptlet to restart units on upgrade")
+	c.Assert(alerts[1].Line.Index, chk.Equals, 66)
+	c.Assert(alerts[2].Info, chk.Equals, "Package agent contains systemd units, but %post scriptlet doesn't contain %systemd_user_post macro")
+	c.Assert(alerts[2].Line.Index, chk.Equals, 70)
+	c.Assert(alerts[3].Info, chk.Equals, "Package agent contains systemd units, but %preun scriptlet doesn't contain %systemd_user_preun macro")
+	c.Assert(alerts[4].Info, chk.Equals, "Package agent contains systemd units, but %postun scriptlet doesn't contain %systemd_user_postun_with_restart macro")
+	c.Assert(alerts[5].Info, chk.Equals, "Spec contains systemd units, but systemd-rpm-macros is not listed in BuildRequires")
+	c.Assert(alerts[5].Line.Index, chk.Equals, -1)
+	c.Assert(alerts[6].Info, chk.Equals, "Don't use \"systemctl daemon-reload\" in scriptlets, %systemd_* macros reload systemd configuration automatically")
+	c.Assert(alerts[6].Line.Index, chk.Equals, 53)
+	c.Assert(alerts[7].Info, chk.Equals, "Use %systemd_post macro instead of \"systemctl enable\" in scriptlets")
+	c.Assert(alerts[7].Line.Index, chk.Equals, 59)
+
+	s, err = spec.Read("../testdata/test.spec")
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(s, chk.NotNil)
+
+	c.Assert(checkForSystemdScriptlets("", s), chk.HasLen, 0)
+}
+
+func (sc *CheckSuite) TestCheckForUserCreation(c *chk.C) {
+	s, err := spec.Read("../testdata/test_22.spec")
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(s, chk.NotNil)
+
+	alerts := checkForUserCreation("", s)
+
+	c.Assert(alerts, chk.HasLen, 5)
+	c.Assert(alerts[0].Info, chk.Equals, "Use sysusers.d file with %sysusers_create_compat macro instead of useradd/groupadd")
+	c.Assert(alerts[0].Line.Index, chk.Equals, 64)
+	c.Assert(alerts[1].Info, chk.Equals, "Main package creates users or groups in %pre scriptlet, but doesn't require shadow-utils (Requires(pre): shadow-utils)")
+	c.Assert(alerts[1].Line.Index, chk.Equals, 64)
+	c.Assert(alerts[2].Info, chk.Equals, "Use sysusers.d file with %sysusers_create_compat macro instead of useradd/groupadd")
+	c.Assert(alerts[2].Line.Index, chk.Equals, 70)
+	c.Assert(alerts[3].Info, chk.Equals, "Check if user or group already exists using getent before calling useradd")
+	c.Assert(alerts[3].Line.Index, chk.Equals, 70)
+	c.Assert(alerts[4].Info, chk.Equals, "Failed useradd call will abort transaction, add \"|| :\" to the end of command")
+	c.Assert(alerts[4].Line.Index, chk.Equals, 70)
+}
+
+func (sc *CheckSuite) TestCheckScriptletsArgument(c *chk.C) {
+	s, err := spec.Read("../testdata/test_23.spec")
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(s, chk.NotNil)
+
+	alerts := checkScriptletsArgument("", s)
+
+	c.Assert(alerts, chk.HasLen, 5)
+	c.Assert(alerts[0].Info, chk.Equals, "Destructive command \"systemctl stop\" executed in %pre scriptlet without checking $1 to distinguish erase from upgrade")
+	c.Assert(alerts[0].Line.Index, chk.Equals, 60)
+	c.Assert(alerts[1].Info, chk.Equals, "The last command in %pre scriptlet can fail and abort transaction, add \"|| :\" to the end of it")
+	c.Assert(alerts[1].Line.Index, chk.Equals, 60)
+	c.Assert(alerts[2].Info, chk.Equals, "The last command in %post scriptlet can fail and abort transaction, add \"|| :\" to the end of it")
+	c.Assert(alerts[2].Line.Index, chk.Equals, 64)
+	c.Assert(alerts[3].Info, chk.Equals, "Destructive command \"rm\" executed in %postun scriptlet without checking $1 to distinguish erase from upgrade")
+	c.Assert(alerts[3].Line.Index, chk.Equals, 77)
+	c.Assert(alerts[4].Info, chk.Equals, "Destructive command \"rm\" executed in %postun scriptlet without checking $1 to distinguish erase from upgrade")
+	c.Assert(alerts[4].Line.Index, chk.Equals, 78)
+
+	c.Assert(canCommandFail(spec.Line{Text: "rm -f file ||   exit 0"}), chk.Equals, false)
+	c.Assert(canCommandFail(spec.Line{Text: "rm -f file ||true"}), chk.Equals, false)
+	c.Assert(canCommandFail(spec.Line{Text: "rm -f file || echo fail"}), chk.Equals, true)
+	c.Assert(canCommandFail(spec.Line{Text: "rm -f file"}), chk.Equals, true)
+
+	c.Assert(isShellKeyword("fi", "fi"), chk.Equals, true)
+	c.Assert(isShellKeyword("fi;", "fi"), chk.Equals, true)
+	c.Assert(isShellKeyword("esac # end", "esac"), chk.Equals, true)
+	c.Assert(isShellKeyword("find /tmp", "fi"), chk.Equals, false)
+	c.Assert(isShellKeyword("esac_cleanup", "esac"), chk.Equals, false)
+
+	c.Assert(hasShellKeywordSuffix("if true; then :; fi", "fi"), chk.Equals, true)
+	c.Assert(hasShellKeywordSuffix("case $1 in 0) : ;; esac;", "esac"), chk.Equals, true)
+	c.Assert(hasShellKeywordSuffix("rm -f %{_bindir}/wifi", "fi"), chk.Equals, false)
+	c.Assert(getShellCondition("if [ $1 -eq 0 ]; then rm -rf /tmp/$1; fi"), chk.Equals, "if [ $1 -eq 0 ]; ")
+	c.Assert(getShellCondition(`case "$1" in 0) rm -rf /tmp ;; esac`), chk.Equals, `case "$1"`)
+}
+
+func (sc *CheckSuite) TestCheckShellSyntax(c *chk.C) {
+	s, err := spec.Read("../testdata/test_24.spec")
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(s, chk.NotNil)
+
+	alerts := checkShellSyntax("", s)
+
+	c.Assert(alerts, chk.HasLen, 3)
+	c.Assert(alerts[0].Info, chk.Equals, "Shell syntax error in %build section: reached EOF without closing quote \"")
+	c.Assert(alerts[0].Line.Index, chk.Equals, 52)
+	c.Assert(alerts[1].Info, chk.Equals, "Shell syntax error in %install section: << must be followed by a word")
+	c.Assert(alerts[1].Line.Index, chk.Equals, 63)
+	c.Assert(alerts[2].Info, chk.Equals, "Shell syntax error in %post section: if statement must end with \"fi\"")
+	c.Assert(alerts[2].Line.Index, chk.Equals, 70)
+
+	c.Assert(maskMacros("%{__make} %{?_smp_mflags} 100%% %name %(echo 1) %"), chk.Equals, "_________ _______________ 100% _____ _________ %")
+	c.Assert(maskMacros("v=${version%%.*}"), chk.Equals, "v=${version%.*}")
+	c.Assert(maskMacros("date +%%Y"), chk.Equals, "date +%Y")
+
+	c.Assert(parseShellScript([]spec.Line{
+		{Index: 1, Text: "v=${version%%.*}"},
+		{Index: 2, Text: "d=$(date +%%Y-%%m-%%d)"},
+		{Index: 3, Text: "echo ${v%%-*} %{name}"},
+	}), chk.IsNil)
+}
+
+func (sc *CheckSuite) TestCheckForSharedLibraries(c *chk.C) {
+	s, err := spec.Read("../testdata/test_25.spec")
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(s, chk.NotNil)
+
+	alerts := checkForSharedLibraries("", s)
+
+	c.Assert(alerts, chk.HasLen, 4)
+	c.Assert(alerts[0].Info, chk.Equals, "Main package contains shared libraries, but %postun scriptlet doesn't run ldconfig (use %ldconfig_scriptlets macro)")
+	c.Assert(alerts[0].Line.Index, chk.Equals, 68)
+	c.Assert(alerts[1].Info, chk.Equals, "Unversioned shared library symlink must be packaged in -devel subpackage")
+	c.Assert(alerts[1].Line.Index, chk.Equals, 69)
+	c.Assert(alerts[2].Info, chk.Equals, "Header files must be packaged in -devel subpackage")
+	c.Assert(alerts[2].Line.Index, chk.Equals, 70)
+	c.Assert(alerts[3].Info, chk.Equals, "Use %{?_isa} in requirement of base package to match its architecture")
+	c.Assert(alerts[3].Line.Index, chk.Equals, 37)
+	c.Assert(alerts[3].Fix, chk.Equals, "Requires:           %{name}-libs%{?_isa} = %{version}-%{release}")
+
+	s.Targets = []string{"el8", "almalinux9"}
+	alerts = checkForSharedLibraries("", s)
+
+	c.Assert(alerts, chk.HasLen, 5)
+	c.Assert(alerts[0].Info, chk.Equals, "ldconfig scriptlets are obsolete on target systems, glibc runs ldconfig using file triggers")
+	c.Assert(alerts[0].Line.Index, chk.Equals, 61)
+	c.Assert(alerts[1].Line.Index, chk.Equals, 58)
+
+	s.Targets = []string{"el7", "el8"}
+	c.Assert(isLDConfigObsolete(s), chk.Equals, false)
+}
+
+func (sc *CheckSuite) TestCheckForMacroUsage(c *chk.C) {
+	s, err := spec.Read("../testdata/test_26.spec")
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(s, chk.NotNil)
+
+	alerts := checkForMacroUsage("", s)
+
+	c.Assert(alerts, chk.HasLen, 5)
+	c.Assert(alerts[0].Info, chk.Equals, "Macro %service_name is defined but never used")
+	c.Assert(alerts[0].Line.Index, chk.Equals, 3)
+	c.Assert(alerts[1].Info, chk.Equals, "Macro %unused_value is defined but never used")
+	c.Assert(alerts[1].Line.Index, chk.Equals, 5)
+	c.Assert(alerts[2].Info, chk.Equals, "Use %global instead of %define for macros defined at top level")
+	c.Assert(alerts[2].Line.Index, chk.Equals, 6)
+	c.Assert(alerts[2].Fix, chk.Equals, "%global service_group perfecto")
+	c.Assert(alerts[3].Info, chk.Equals, "Macro %service_name is already defined on line 3")
+	c.Assert(alerts[3].Line.Index, chk.Equals, 7)
+	c.Assert(alerts[4].Info, chk.Equals, "Macro %servce_name is used but never defined")
+	c.Assert(alerts[4].Level, chk.Equals, LEVEL_WARNING)
+	c.Assert(alerts[4].Line.Index, chk.Equals, 53)
+
+	c.Assert(isBuiltinMacro("SOURCE10"), chk.Equals, true)
+	c.Assert(isBuiltinMacro("__cmake"), chk.Equals, true)
+	c.Assert(isBuiltinMacro("SOURCES"), chk.Equals, false)
+	c.Assert(normalizeMacroName("_without_tests"), chk.Equals, "with_tests")
+
+	for _, pm := range pathMacroSlice {
+		name := strings.Trim(pm.Name, "%{}")
+		c.Assert(isBuiltinMacro(name), chk.Equals, true, chk.Commentf("Macro %s", name))
+	}
+
+	for _, name := range []string{
+		"_localedir", "_metainfodir", "pypi_source", "modules_source",
+		"_vpath_builddir", "_fillupdir", "forgesource",
+	} {
+		c.Assert(isBuiltinMacro(name), chk.Equals, true, chk.Commentf("Macro %s", name))
+	}
+
+	usages := findMacroUsages(spec.Line{Index: 1}, `printf "%05d %ld %s\n" 1 2 %{name} %{_javadir}`)
+
+	c.Assert(usages, chk.HasLen, 2)
+	c.Assert(usages[0].Name, chk.Equals, "name")
+	c.Assert(usages[1].Name, chk.Equals, "_javadir")
+}
+
+func (sc *CheckSuite) TestCheckBuildConditions(c *chk.C) {
+	s, err := spec.Read("../testdata/test_27.spec")
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(s, chk.NotNil)
+
+	alerts := checkBuildConditions("", s)
+
+	c.Assert(alerts, chk.HasLen, 5)
+	c.Assert(alerts[0].Info, chk.Equals, "Build condition \"debug\" must have default value (%bcond name 0|1)")
+	c.Assert(alerts[0].Line.Index, chk.Equals, 6)
+	c.Assert(alerts[1].Info, chk.Equals, "Build condition \"unused\" is declared but never tested")
+	c.Assert(alerts[1].Line.Index, chk.Equals, 7)
+	c.Assert(alerts[2].Info, chk.Equals, "Build condition \"lto\" is tested but never declared with %bcond")
+	c.Assert(alerts[2].Line.Index, chk.Equals, 60)
+	c.Assert(alerts[3].Info, chk.Equals, "Use %{with tests} or %{without tests} instead of %_with_tests, it is defined only if condition is set from command line")
+	c.Assert(alerts[3].Line.Index, chk.Equals, 63)
+	c.Assert(alerts[4].Info, chk.Equals, "Use %{with docs} instead of negated %{without docs}")
+	c.Assert(alerts[4].Line.Index, chk.Equals, 46)
+	c.Assert(alerts[4].Fix, chk.Equals, "%if %{with docs}")
+
+	alerts = checkBuildConditions("", s.Variant([]string{"docs"}, nil))
+
+	c.Assert(alerts, chk.HasLen, 5)
+}
+
+func (sc *CheckSuite) TestCheckForDeprecatedSyntax(c *chk.C) {
+	s, err := spec.Read("../testdata/test_28.spec")
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(s, chk.NotNil)
+
+	alerts := checkForDeprecatedSyntax("", s)
+
+	c.Assert(alerts, chk.HasLen, 6)
+	c.Assert(alerts[0].Info, chk.Equals, "PreReq tag is obsolete since RPM 4.6, use Requires(pre) and Requires(post) instead")
+	c.Assert(alerts[0].Line.Index, chk.Equals, 19)
+	c.Assert(alerts[1].Info, chk.Equals, "Copyright tag is obsolete since RPM 4.0, use License tag instead")
+	c.Assert(alerts[1].Line.Index, chk.Equals, 8)
+	c.Assert(alerts[1].Level, chk.Equals, LEVEL_ERROR)
+	c.Assert(alerts[2].Info, chk.Equals, "%makeinstall macro is obsolete since RPM 4.10, use %make_install instead")
+	c.Assert(alerts[2].Line.Index, chk.Equals, 41)
+	c.Assert(alerts[3].Info, chk.Equals, "%{__python} macro is obsolete since EL8, use %{__python3} instead")
+	c.Assert(alerts[3].Line.Index, chk.Equals, 35)
+	c.Assert(alerts[4].Info, chk.Equals, "%py_requires macro is obsolete since EL8, add BuildRequires: python3-devel instead")
+	c.Assert(alerts[4].Line.Index, chk.Equals, 34)
+	c.Assert(alerts[5].Info, chk.Equals, "%patchN syntax is obsolete since RPM 4.20, use %patch -P N instead")
+	c.Assert(alerts[5].Line.Index, chk.Equals, 31)
+
+	SetPolicy(&Policy{Levels: map[string]string{"copyright-tag": "warning"}})
+
+	alerts = checkForDeprecatedSyntax("", s)
+
+	c.Assert(alerts, chk.HasLen, 6)
+	c.Assert(alerts[1].Level, chk.Equals, LEVEL_WARNING)
+	c.Assert(alerts[5].Level, chk.Equals, LEVEL_ERROR)
+
+	SetPolicy(nil)
+
+	for _, d := range deprecations {
+		if !d.IsOptional {
+			continue
+		}
+
+		lines := d.Find(s, nil)
+
+		switch d.Name {
+		case "buildroot-tag":
+			c.Assert(lines, chk.HasLen, 1)
+			c.Assert(lines[0].Index, chk.Equals, 11)
+		case "clean-section":
+			c.Assert(lines, chk.HasLen, 1)
+			c.Assert(lines[0].Index, chk.Equals, 44)
+		case "install-cleanup":
+			c.Assert(lines, chk.HasLen, 1)
+			c.Assert(lines[0].Index, chk.Equals, 39)
+		case "smp-mflags-macro":
+			c.Assert(lines, chk.HasLen, 1)
+			c.Assert(lines[0].Index, chk.Equals, 36)
+		}
+	}
+}
+
+func (sc *CheckSuite) TestCheckForNetworkAccess(c *chk.C) {
+	s, err := spec.Read("../testdata/test_29.spec")
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(s, chk.NotNil)
+
+	alerts := checkForNetworkAccess("", s)
+
+	c.Assert(alerts, chk.HasLen, 6)
+	c.Assert(alerts[0].Info, chk.Equals, "Command \"git clone\" requires network access which is not available on build systems (Koji, mock)")
+	c.Assert(alerts[0].Line.Index, chk.Equals, 25)
+	c.Assert(alerts[1].Info, chk.Equals, "Don't download https://source.kaos.st/perfecto/data.tar.gz in %build section, declare it as Source instead")
+	c.Assert(alerts[1].Line.Index, chk.Equals, 28)
+	c.Assert(alerts[2].Info, chk.Equals, "Command \"go get\" requires network access which is not available on build systems (Koji, mock)")
+	c.Assert(alerts[2].Line.Index, chk.Equals, 29)
+	c.Assert(alerts[3].Info, chk.Equals, "Command \"pip install\" requires network access which is not available on build systems (Koji, mock)")
+	c.Assert(alerts[3].Line.Index, chk.Equals, 40)
+	c.Assert(alerts[4].Info, chk.Equals, "Command \"npm install\" requires network access which is not available on build systems (Koji, mock)")
+	c.Assert(alerts[4].Line.Index, chk.Equals, 44)
+	c.Assert(alerts[5].Info, chk.Equals, "Command \"go mod download\" requires network access which is not available on build systems (Koji, mock)")
+	c.Assert(alerts[5].Line.Index, chk.Equals, 46)
+}
+
+func (sc *CheckSuite) TestCheckSourceURLs(c *chk.C) {
+	s, err := spec.Read("../testdata/test_30.spec")
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(s, chk.NotNil)
+
+	alerts := checkSourceURLs("", s)
+
+	c.Assert(alerts, chk.HasLen, 6)
+	c.Assert(alerts[0].Info, chk.Equals, "Source archive perfecto-1.2.3 doesn't match directory perfecto-v1.2.3 expected by %setup (use -n option)")
+	c.Assert(alerts[0].Line.Index, chk.Equals, 32)
+	c.Assert(alerts[1].Info, chk.Equals, "Source URL contains hardcoded version 1.2.3, use %{version} macro instead")
+	c.Assert(alerts[1].Line.Index, chk.Equals, 16)
+	c.Assert(alerts[1].Fix, chk.Equals, "Source1:            https://codeload.github.com/essentialkaos/ek/tar.gz/v%{version}")
+	c.Assert(alerts[2].Info, chk.Equals, "Use canonical GitHub archive URL for source")
+	c.Assert(alerts[2].Line.Index, chk.Equals, 16)
+	c.Assert(alerts[3].Info, chk.Equals, "Use canonical PyPI archive URL for source")
+	c.Assert(alerts[3].Line.Index, chk.Equals, 17)
+	c.Assert(alerts[4].Info, chk.Equals, "Use canonical crates.io archive URL for source")
+	c.Assert(alerts[4].Line.Index, chk.Equals, 18)
+	c.Assert(alerts[5].Info, chk.Equals, "Use canonical GitLab archive URL for source")
+	c.Assert(alerts[5].Line.Index, chk.Equals, 19)
+
+	s, err = spec.Read("../testdata/test_36.spec")
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(s, chk.NotNil)
+
+	alerts = checkSourceURLs("", s)
+
+	c.Assert(alerts, chk.HasLen, 3)
+	c.Assert(alerts[0].Info, chk.Equals, "Source archive perfecto-1.2.3 doesn't match directory perfecto-v1.2.3 expected by %setup (use -n option)")
+	c.Assert(alerts[0].Line.Index, chk.Equals, 28)
+	c.Assert(alerts[1].Info, chk.Equals, "Source URL contains hardcoded version 1.2.3, use %{version} macro instead")
+	c.Assert(alerts[1].Line.Index, chk.Equals, 16)
+	c.Assert(alerts[2].Info, chk.Equals, "Use canonical GitHub archive URL for source")
+	c.Assert(alerts[2].Line.Index, chk.Equals, 16)
+
+	c.Assert(getArchiveDir("https://github.com/essentialkaos/perfecto/archive/v1.2.3.tar.gz"), chk.Equals, "perfecto-1.2.3")
+	c.Assert(getArchiveDir("https://github.com/essentialkaos/perfecto/archive/refs/tags/v1.2.3.tar.gz"), chk.Equals, "perfecto-1.2.3")
+	c.Assert(getArchiveDir("https://github.com/essentialkaos/perfecto/archive/v1.2.3/perfecto-1.2.3.tar.gz"), chk.Equals, "perfecto-1.2.3")
+	c.Assert(getArchiveDir("https://github.com/essentialkaos/perfecto/archive/v1.2.3/source.tar.gz"), chk.Equals, "perfecto-1.2.3")
+	c.Assert(getArchiveDir("https://github.com/essentialkaos/perfecto/archive/v1.2.3.tar.gz#/perfecto.tar.gz"), chk.Equals, "perfecto-1.2.3")
+	c.Assert(getArchiveDir("https://github.com/essentialkaos/perfecto/archive/refs/heads/master.zip"), chk.Equals, "perfecto-master")
+	c.Assert(getArchiveDir("https://github.com/essentialkaos/perfecto/archive/version-1.2.3.tar.gz"), chk.Equals, "perfecto-version-1.2.3")
+	c.Assert(getArchiveDir("https://github.com/essentialkaos/perfecto/releases/download/v1.2.3/perfecto-1.2.3.tar.xz"), chk.Equals, "perfecto-1.2.3")
+	c.Assert(getArchiveDir("https://source.kaos.st/perfecto/perfecto-1.2.3.tar.bz2"), chk.Equals, "perfecto-1.2.3")
+	c.Assert(getArchiveDir("https://source.kaos.st/perfecto/README.md"), chk.Equals, "")
+}
+
+func (sc *CheckSuite) TestRulePacks(c *chk.C) {
+	packs := getRulePacks()
+
+	c.Assert(packs, chk.HasLen, 4)
+
+	s, err := spec.Read("../testdata/test.spec")
+
+	c.Assert(err, chk.IsNil)
+
+	for _, pack := range packs {
+		c.Assert(pack.IsActive(s), chk.Equals, false)
+	}
+
+	s, err = spec.Read("../testdata/test_31.spec")
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(packs[0].IsActive(s), chk.Equals, true)
+	c.Assert(packs[1].IsActive(s), chk.Equals, false)
+
+	alerts := checkGoCommands("", s)
+
+	c.Assert(alerts, chk.HasLen, 2)
+	c.Assert(alerts[0].Info, chk.Equals, `Use %gobuild macro instead of "go build" command`)
+	c.Assert(alerts[0].Line.Index, chk.Equals, 26)
+	c.Assert(alerts[1].Info, chk.Equals, `Use %gotest macro instead of "go test" command`)
+	c.Assert(alerts[1].Line.Index, chk.Equals, 35)
+
+	s, err = spec.Read("../testdata/test_32.spec")
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(packs[1].IsActive(s), chk.Equals, true)
+
+	alerts = checkPythonCommands("", s)
+
+	c.Assert(alerts, chk.HasLen, 2)
+	c.Assert(alerts[0].Info, chk.Equals, `Use %pyproject_wheel macro instead of "setup.py build" command`)
+	c.Assert(alerts[0].Line.Index, chk.Equals, 30)
+	c.Assert(alerts[1].Info, chk.Equals, `Use %pyproject_install macro instead of "pip install" command`)
+	c.Assert(alerts[1].Line.Index, chk.Equals, 35)
+
+	alerts = checkPythonBuildRequires("", s)
+
+	c.Assert(alerts, chk.HasLen, 1)
+	c.Assert(alerts[0].Info, chk.Equals, "Section %generate_buildrequires doesn't contain %pyproject_buildrequires")
+	c.Assert(alerts[0].Line.Index, chk.Equals, 25)
+
+	alerts = checkPythonImportCheck("", s)
 
 	c.Assert(alerts, chk.HasLen, 1)
+	c.Assert(alerts[0].Info, chk.Equals, "Use %py3_check_import in %check section for checking module import")
+	c.Assert(alerts[0].Line.Index, chk.Equals, 37)
+
+	s, err = spec.Read("../testdata/test_33.spec")
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(packs[2].IsActive(s), chk.Equals, true)
+
+	alerts = checkRustCommands("", s)
+
+	c.Assert(alerts, chk.HasLen, 2)
+	c.Assert(alerts[0].Info, chk.Equals, `Use %cargo_build macro instead of "cargo build" command`)
+	c.Assert(alerts[0].Line.Index, chk.Equals, 27)
+	c.Assert(alerts[1].Info, chk.Equals, `Use %cargo_install macro instead of "cargo install" command`)
+	c.Assert(alerts[1].Line.Index, chk.Equals, 32)
+
+	s, err = spec.Read("../testdata/test_34.spec")
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(packs[3].IsActive(s), chk.Equals, true)
+
+	alerts = checkNodeCommands("", s)
+
+	c.Assert(alerts, chk.HasLen, 1)
+	c.Assert(alerts[0].Info, chk.Equals, `Use %nodejs_symlink_deps macro instead of "npm install" command`)
+	c.Assert(alerts[0].Line.Index, chk.Equals, 26)
+
+	alerts = checkNodeModulesPath("", s)
+
+	c.Assert(alerts, chk.HasLen, 1)
+	c.Assert(alerts[0].Line.Index, chk.Equals, 31)
+
+	r := Check(s, false, "", []string{"NJ"})
+
+	for _, alert := range r.Warnings {
+		c.Assert(alert.ID != "NJ1" || alert.IsIgnored, chk.Equals, true)
+	}
+
+	c.Assert(isIgnoredCheck([]string{"PY"}, "PY2"), chk.Equals, true)
+	c.Assert(isIgnoredCheck([]string{"PY2"}, "PY2"), chk.Equals, true)
+	c.Assert(isIgnoredCheck([]string{"PF1"}, "PF12"), chk.Equals, false)
+	c.Assert(isIgnoredCheck([]string{"P"}, "PY2"), chk.Equals, false)
+	c.Assert(isIgnoredCheck([]string{""}, "PY2"), chk.Equals, false)
+
+	s = &spec.Spec{}
+
+	c.Assert(checkGoCommands("", s), chk.IsNil)
+	c.Assert(checkPythonBuildRequires("", s), chk.IsNil)
+	c.Assert(checkPythonImportCheck("", s), chk.IsNil)
+	c.Assert(checkNodeModulesPath("", s), chk.IsNil)
+}
+
+func (sc *CheckSuite) TestPolicies(c *chk.C) {
+	c.Assert(GetPolicies(map[string]*Policy{"custom": {}, "kaos": {}}), chk.DeepEquals,
+		[]string{"custom", "epel", "fedora", "kaos", "opensuse"})
+
+	p, err := GetPolicy(POLICY_EPEL, nil)
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(p.Name, chk.Equals, POLICY_EPEL)
+	c.Assert(p.IsDisabled("PF12"), chk.Equals, true)
+	c.Assert(p.IsDisabled("PF1"), chk.Equals, false)
+	c.Assert(p.HasDeprecation("buildroot-tag"), chk.Equals, true)
+	c.Assert(p.GetBool("PF9", "require_group"), chk.Equals, false)
+
+	level, ok := p.GetLevel("PF29")
+	c.Assert(ok, chk.Equals, true)
+	c.Assert(level, chk.Equals, LEVEL_WARNING)
+	_, ok = p.GetLevel("PF1")
+	c.Assert(ok, chk.Equals, false)
+
+	config, err := ReadConfig("../testdata/config.toml")
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(config.Policy, chk.Equals, "mycorp")
+
+	p, err = config.GetPolicy(config.Policy)
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(p.IsDisabled("PF12"), chk.Equals, true)
+	c.Assert(p.IsDisabled("PF13"), chk.Equals, false)
+	c.Assert(p.IsDisabled("PF20"), chk.Equals, true)
+	c.Assert(p.GetInt("PF2", "max_length"), chk.Equals, 100)
+	c.Assert(p.GetStrings("PF14", "binaries"), chk.DeepEquals, []string{"cp", "mv"})
+	c.Assert(policies[POLICY_FEDORA].Params["PF2"], chk.IsNil)
+
+	_, err = ReadConfig("../testdata/config_unknown.toml")
+	c.Assert(err, chk.ErrorMatches, `Configuration file .* contains unknown key "unknown"`)
+	_, err = ReadConfig("../testdata/unknown.toml")
+	c.Assert(err, chk.NotNil)
+
+	_, err = GetPolicy("unknown", nil)
+	c.Assert(err, chk.ErrorMatches, `Unknown policy "unknown"`)
+	_, err = GetPolicy("a", map[string]*Policy{"a": {Extends: "b"}, "b": {Extends: "a"}})
+	c.Assert(err, chk.ErrorMatches, `Policy "a" has circular dependency`)
+	_, err = GetPolicy("a", map[string]*Policy{"a": {Levels: map[string]string{"PF1": "fatal"}}})
+	c.Assert(err, chk.ErrorMatches, `Unknown alert level "fatal" for check PF1`)
+	_, err = GetPolicy("a", map[string]*Policy{"a": {Deprecations: []string{"unknown"}}})
+	c.Assert(err, chk.ErrorMatches, `Unknown deprecation "unknown"`)
+
+	s, err := spec.Read("../testdata/test_7.spec")
+
+	c.Assert(err, chk.IsNil)
+
+	SetPolicy(p)
+
+	c.Assert(checkForLineLength("PF2", s), chk.HasLen, 0)
+	c.Assert(runChecker("PF20", checkURLForHTTPS, s), chk.IsNil)
+
+	s19, err := spec.Read("../testdata/test_19.spec")
+
+	c.Assert(err, chk.IsNil)
+
+	alerts := runChecker("PF28", checkForLongSummary, s19)
+
+	c.Assert(alerts, chk.HasLen, 1)
+	c.Assert(alerts[0].Level, chk.Equals, LEVEL_WARNING)
+
+	r := Check(s, false, "", nil)
+
+	c.Assert(r.Policy, chk.Equals, "mycorp")
+
+	SetPolicy(nil)
+
+	c.Assert(checkForLineLength("PF2", s), chk.HasLen, 1)
+}
+
+func (sc *CheckSuite) TestParams(c *chk.C) {
+	c.Assert(GetParams("PF2"), chk.HasLen, 1)
+	c.Assert(GetParams("PF1"), chk.HasLen, 0)
+
+	p := &Policy{}
+
+	c.Assert(p.GetInt("PF2", "max_length"), chk.Equals, 80)
+	c.Assert(p.GetInt("PF2", "unknown"), chk.Equals, 0)
+	c.Assert(p.GetBool("PF9", "require_group"), chk.Equals, true)
+	c.Assert(p.GetStrings("PF14", "binaries"), chk.DeepEquals, binariesAsMacro)
+	c.Assert(p.GetPairs("PF4", "paths"), chk.HasLen, len(pathMacroSlice))
+
+	config := &Config{Checks: map[string]map[string]any{
+		"PF2":  {"max_length": int64(100)},
+		"PF4":  {"paths": []any{[]any{"/opt", "%{_opt}"}}},
+		"PF28": {"limit": 50},
+	}}
+
+	p, err := config.GetPolicy("")
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(p.Name, chk.Equals, POLICY_KAOS)
+	c.Assert(p.GetInt("PF2", "max_length"), chk.Equals, 100)
+	c.Assert(p.GetInt("PF28", "limit"), chk.Equals, 50)
+	c.Assert(p.GetPairs("PF4", "paths"), chk.DeepEquals, [][2]string{{"/opt", "%{_opt}"}})
+
+	SetPolicy(p)
+
+	alerts := checkForNonMacroPaths("PF4", &spec.Spec{Data: []spec.Line{
+		{Index: 1, Text: "%install"},
+		{Index: 2, Text: "cp app /opt/app"},
+		{Index: 3, Text: "cp app /usr/bin/app"},
+	}})
+
+	SetPolicy(nil)
+
+	c.Assert(alerts, chk.HasLen, 1)
+	c.Assert(alerts[0].Info, chk.Equals, `Path "/opt" should be used as macro "%{_opt}"`)
+
+	for _, tc := range []struct {
+		params map[string]map[string]any
+		err    string
+	}{
+		{map[string]map[string]any{"PF1": {"test": 1}}, "Check PF1 doesn't have parameters"},
+		{map[string]map[string]any{"PF2": {"test": 1}}, `Unknown parameter "test" for check PF2`},
+		{map[string]map[string]any{"PF2": {"max_length": "1"}}, `Parameter "max_length" for check PF2 must be an integer`},
+		{map[string]map[string]any{"PF2": {"max_length": 0}}, `Parameter "max_length" for check PF2 must be a positive integer`},
+		{map[string]map[string]any{"PF12": {"length": int64(-10)}}, `Parameter "length" for check PF12 must be a positive integer`},
+		{map[string]map[string]any{"PF28": {"limit": -1}}, `Parameter "limit" for check PF28 must be a positive integer`},
+		{map[string]map[string]any{"PF9": {"require_group": 1}}, `Parameter "require_group" for check PF9 must be a boolean`},
+		{map[string]map[string]any{"PF14": {"binaries": []any{1}}}, `Parameter "binaries" for check PF14 must be a list of strings`},
+		{map[string]map[string]any{"PF4": {"paths": []any{[]any{"/opt"}}}}, `Parameter "paths" for check PF4 must be a list of string pairs`},
+	} {
+		_, err = (&Config{Checks: tc.params}).GetPolicy("")
+		c.Assert(err, chk.ErrorMatches, tc.err)
+	}
+}
+
+func (sc *CheckSuite) TestCustomRules(c *chk.C) {
+	config, err := ReadConfig("../testdata/config_rules.toml")
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(config.Rules, chk.HasLen, 4)
+	c.Assert(SetRules(config.Rules), chk.IsNil)
+
+	s, err := spec.Read("../testdata/test_35.spec")
+
+	c.Assert(err, chk.IsNil)
+
+	r := Check(s, false, "", []string{"MY3"})
+
+	SetRules(nil)
+
+	c.Assert(r.Notices, chk.HasLen, 1)
+	c.Assert(r.Notices[0].ID, chk.Equals, "MY3")
+	c.Assert(r.Notices[0].Line.Index, chk.Equals, 26)
+	c.Assert(r.Notices[0].IsIgnored, chk.Equals, true)
+	c.Assert(r.Errors, chk.HasLen, 2)
+	c.Assert(r.Errors[0].ID, chk.Equals, "MY1")
+	c.Assert(r.Errors[0].Info, chk.Equals, "Never use /opt, use %{_prefix} instead")
+	c.Assert(r.Errors[0].Line.Index, chk.Equals, 32)
+	c.Assert(r.Errors[1].Line.Index, chk.Equals, 40)
+
+	var ids []string
+
+	for _, alert := range r.Warnings {
+		ids = append(ids, fmt.Sprintf("%s:%d", alert.ID, alert.Line.Index))
+	}
+
+	c.Assert(ids, chk.DeepEquals, []string{"MY2:-1", "MY4:43"})
+
+	c.Assert(SetRules([]*Rule{{ID: "MY1", Message: "Test", Match: "."}, {ID: "MY1", Message: "Test", Match: "."}}),
+		chk.ErrorMatches, "Rule MY1 is defined more than once")
+
+	for _, tc := range []struct {
+		rule *Rule
+		err  string
+	}{
+		{&Rule{ID: "my1"}, `Rule ID "my1" is invalid, .*`},
+		{&Rule{ID: "PF100"}, "Rule PF100 uses prefix reserved for built-in checks"},
+		{&Rule{ID: "MY1"}, "Rule MY1 doesn't have message"},
+		{&Rule{ID: "MY1", Message: "Test"}, "Rule MY1 doesn't have pattern"},
+		{&Rule{ID: "MY1", Message: "Test", Match: ".", Level: "fatal"}, `Rule MY1 has unknown alert level "fatal"`},
+		{&Rule{ID: "MY1", Message: "Test", Match: ".", Sections: []string{"unknown"}}, `Rule MY1 contains unknown section "unknown"`},
+		{&Rule{ID: "MY1", Message: "Test", Match: ".", Comment: "never"}, `Rule MY1 contains unknown filter mode "never"`},
+		{&Rule{ID: "MY1", Message: "Test", Match: "("}, "Rule MY1 contains invalid pattern: .*"},
+		{&Rule{ID: "MY1", Message: "Test", Match: ".", Exclude: []string{"("}}, "Rule MY1 contains invalid exclude pattern: .*"},
+	} {
+		c.Assert(tc.rule.Compile(), chk.ErrorMatches, tc.err)
+	}
+
+	c.Assert((&Rule{}).Check("", s), chk.IsNil)
+}
+
+func (sc *CheckSuite) TestPlugins(c *chk.C) {
+	path := os.Getenv("PATH")
+	os.Setenv("PATH", "../testdata/plugins"+string(os.PathListSeparator)+path)
+	defer os.Setenv("PATH", path)
+
+	list, err := FindPlugins([]string{"../testdata/plugins/perfecto-plugin-test"}, 0)
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(list, chk.HasLen, 3)
+	c.Assert(list[0].Name, chk.Equals, "test")
+	c.Assert(list[0].Timeout, chk.Equals, DEFAULT_PLUGIN_TIMEOUT)
+	c.Assert(list[1].Name, chk.Equals, "broken")
+	c.Assert(list[2].Name, chk.Equals, "slow")
+
+	list[2].Timeout = 100 * time.Millisecond
+
+	s, err := spec.Read("../testdata/test_1.spec")
+
+	c.Assert(err, chk.IsNil)
+
+	SetPlugins(list)
+	SetPolicy(&Policy{
+		Disabled: []string{"PF"},
+		Levels:   map[string]string{"test/T2": "critical"},
+	})
+
+	r := Check(s, false, "", []string{"test/T1", "slow"})
+	checks, errs := GetChecks()
+
+	SetPlugins(nil)
+	SetPolicy(nil)
+
+	c.Assert(r.Warnings, chk.HasLen, 1)
+	c.Assert(r.Warnings[0].ID, chk.Equals, "test/T1")
+	c.Assert(r.Warnings[0].Info, chk.Equals, "Test alert")
+	c.Assert(r.Warnings[0].Line, chk.DeepEquals, s.GetLine(5))
+	c.Assert(r.Warnings[0].IsIgnored, chk.Equals, true)
+	c.Assert(r.Criticals, chk.HasLen, 1)
+	c.Assert(r.Criticals[0].ID, chk.Equals, "test/T2")
+	c.Assert(r.Criticals[0].Line.Index, chk.Equals, -1)
+	c.Assert(r.Errors, chk.HasLen, 2)
+	c.Assert(r.Errors[0].ID, chk.Equals, "broken")
+	c.Assert(r.Errors[0].Info, chk.Equals, `Plugin broken failed: unknown alert level "fatal"`)
+	c.Assert(r.Errors[1].ID, chk.Equals, "slow")
+	c.Assert(r.Errors[1].Info, chk.Equals, "Plugin slow failed: timeout (100ms) exceeded")
+	c.Assert(r.Errors[1].IsIgnored, chk.Equals, true)
+
+	c.Assert(errs, chk.HasLen, 2)
+	c.Assert(errs[0], chk.ErrorMatches, `Plugin broken failed: exit status 1 \(Broken plugin\)`)
+	c.Assert(errs[1], chk.ErrorMatches, `Plugin slow failed: timeout \(100ms\) exceeded`)
+	c.Assert(checks[len(checks)-2], chk.DeepEquals, CheckInfo{"test/T1", "Plugin test", "Test check", false})
+	c.Assert(checks[len(checks)-1], chk.DeepEquals, CheckInfo{"test/T2", "Plugin test", "Another test check", false})
+
+	_, err = FindPlugins([]string{"../testdata/plugins/perfecto-plugin-test", "../testdata/plugins/perfecto-plugin-test"}, 0)
+	c.Assert(err, chk.ErrorMatches, `Plugin "test" is defined more than once`)
+
+	_, err = NewPlugin("../testdata/plugins/unknown", 0)
+	c.Assert(err, chk.ErrorMatches, "Can't use plugin ../testdata/plugins/unknown: .*")
+	_, err = NewPlugin("../testdata/plugins/perfecto-plugin-data", 0)
+	c.Assert(err, chk.ErrorMatches, "Can't use plugin ../testdata/plugins/perfecto-plugin-data: file is not executable")
+	_, err = NewPlugin("../testdata/test.spec", 0)
+	c.Assert(err, chk.NotNil)
+
+	p := &Plugin{Name: "unknown", Path: "../testdata/plugins/unknown", Timeout: time.Second}
+	alerts := p.Check("unknown", s)
+
+	c.Assert(alerts, chk.HasLen, 1)
+	c.Assert(alerts[0].Level, chk.Equals, LEVEL_ERROR)
+
+	c.Assert(isIgnoredCheck([]string{"test"}, "test/T1"), chk.Equals, true)
+	c.Assert(isIgnoredCheck([]string{"tes"}, "test/T1"), chk.Equals, false)
+}
+
+func (sc *CheckSuite) TestSPDXParser(c *chk.C) {
+	ids, err := parseSPDXExpression("MIT")
+	c.Assert(err, chk.IsNil)
+	c.Assert(ids, chk.DeepEquals, []string{"MIT"})
+
+	ids, err = parseSPDXExpression("(MIT OR Apache-2.0) AND GPL-2.0-or-later WITH Classpath-exception-2.0")
+	c.Assert(err, chk.IsNil)
+	c.Assert(ids, chk.DeepEquals, []string{"MIT", "Apache-2.0", "GPL-2.0-or-later"})
+
+	_, err = parseSPDXExpression("")
+	c.Assert(err, chk.ErrorMatches, "expression is empty")
+	_, err = parseSPDXExpression("MIT and Apache-2.0")
+	c.Assert(err, chk.ErrorMatches, `operator "and" must be in upper case`)
+	_, err = parseSPDXExpression("MIT AND")
+	c.Assert(err, chk.ErrorMatches, "license identifier expected")
+	_, err = parseSPDXExpression("MIT Apache-2.0")
+	c.Assert(err, chk.ErrorMatches, `unexpected "Apache-2.0"`)
+	_, err = parseSPDXExpression("MIT AND )")
+	c.Assert(err, chk.ErrorMatches, `unexpected "\)"`)
+	_, err = parseSPDXExpression("GPL-2.0-only WITH")
+	c.Assert(err, chk.ErrorMatches, "exception expected after WITH")
+	_, err = parseSPDXExpression("GPL-2.0-only WITH Foo-exception")
+	c.Assert(err, chk.ErrorMatches, `unknown license exception "Foo-exception"`)
+
+	c.Assert(isSPDXLicense("LicenseRef-Fedora-Public-Domain"), chk.Equals, true)
+	c.Assert(isSPDXLicense("apache-2.0"), chk.Equals, true)
+	c.Assert(isSPDXLicense("Apache-2.0+"), chk.Equals, true)
+	c.Assert(isSPDXLicense("ASL"), chk.Equals, false)
+
+	expr, ambiguous, ok := convertLegacyLicense("GPL-2.0+ or (ASL 2.0 and zlib)")
+	c.Assert(ok, chk.Equals, true)
+	c.Assert(ambiguous, chk.HasLen, 0)
+	c.Assert(expr, chk.Equals, "GPL-2.0-or-later OR (Apache-2.0 AND Zlib)")
+
+	expr, ambiguous, ok = convertLegacyLicense("MIT and Apache-2.0")
+	c.Assert(ok, chk.Equals, true)
+	c.Assert(expr, chk.Equals, "MIT AND Apache-2.0")
+
+	_, ambiguous, ok = convertLegacyLicense("Python and LGPLv2+")
+	c.Assert(ok, chk.Equals, true)
+	c.Assert(ambiguous, chk.DeepEquals, []string{"Python", "LGPLv2+"})
+
+	_, _, ok = convertLegacyLicense("MIT AND Apache-2.0")
+	c.Assert(ok, chk.Equals, false)
+	_, _, ok = convertLegacyLicense("GPLv2+ and Unknown")
+	c.Assert(ok, chk.Equals, false)
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -650,7 +1541,16 @@ func (sc *CheckSuite) TestRPMLintParser(c *chk.C) {
 
 func (sc *CheckSuite) TestAux(c *chk.C) {
 	// This test will fail if new checkers was added
-	c.Assert(getCheckers(), chk.HasLen, 28)
+	c.Assert(getCheckers(), chk.HasLen, 39)
+
+	sa := NewAlert("PF1", LEVEL_NOTICE, "Test", spec.Line{1, "Name: ░ perfecto", false})
+	c.Assert(sa.HasSpan(), chk.Equals, false)
+	c.Assert(sa.WithSpan(-1, 2).HasSpan(), chk.Equals, false)
+	c.Assert(sa.WithSpan(2, 100).HasSpan(), chk.Equals, false)
+	c.Assert(sa.WithSpan(10, 18).Column, chk.Equals, 9)
+	c.Assert(sa.WithSpan(10, 18).EndColumn, chk.Equals, 16)
+	c.Assert(sa.WithEndLine(spec.Line{5, "", false}).EndLine, chk.Equals, 5)
+	c.Assert(sa.WithEndLine(emptyLine).EndLine, chk.Equals, 0)
 
 	r := &Report{}
 	c.Assert(r.IsPerfect, chk.Equals, false)
@@ -690,3 +1590,22 @@ func (sc *CheckSuite) TestAux(c *chk.C) {
 	c.Assert(al.Level, chk.Equals, LEVEL_ERROR)
 	c.Assert(al.Info, chk.Equals, "some error")
 }
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// testProber is prober which redirects probes of spec domains to local servers
+type testProber struct {
+	prober *HTTPProber
+	hosts  map[string]string
+}
+
+// SupportsHTTPS returns true if local server for given domain supports HTTPS
+func (p *testProber) SupportsHTTPS(domain string) bool {
+	address, ok := p.hosts[domain]
+
+	if !ok {
+		return false
+	}
+
+	return p.prober.SupportsHTTPS(address)
+}
@@ -0,0 +1,89 @@
+package check
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Config contains perfecto configuration
+type Config struct {
+	Policy   string                    `toml:"policy"`   // Name of used policy
+	Policies map[string]*Policy        `toml:"policies"` // Custom policies
+	Checks   map[string]map[string]any `toml:"checks"`   // Parameters of checks
+	Rules    []*Rule                   `toml:"rules"`    // Custom rules
+
+	Plugins       []string `toml:"plugins"`        // Paths to plugins executables
+	PluginTimeout int      `toml:"plugin_timeout"` // Plugins execution timeout in seconds
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ReadConfig reads configuration from TOML file
+func ReadConfig(file string) (*Config, error) {
+	config := &Config{}
+	meta, err := toml.DecodeFile(file, config)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't parse configuration file %s: %w", file, err)
+	}
+
+	undecoded := meta.Undecoded()
+
+	if len(undecoded) != 0 {
+		return nil, fmt.Errorf("Configuration file %s contains unknown key %q", file, undecoded[0].String())
+	}
+
+	if config.PluginTimeout < 0 {
+		return nil, fmt.Errorf("Configuration file %s is invalid: plugins timeout can't be negative", file)
+	}
+
+	_, err = config.GetPolicy(config.Policy)
+
+	if err != nil {
+		return nil, fmt.Errorf("Configuration file %s is invalid: %w", file, err)
+	}
+
+	for _, rule := range config.Rules {
+		err = rule.Compile()
+
+		if err != nil {
+			return nil, fmt.Errorf("Configuration file %s is invalid: %w", file, err)
+		}
+	}
+
+	return config, nil
+}
+
+// GetPolicy returns policy with given name with parameters of checks from
+// configuration. If name is empty default policy is used.
+func (c *Config) GetPolicy(name string) (*Policy, error) {
+	if name == "" {
+		name = POLICY_KAOS
+	}
+
+	p, err := GetPolicy(name, c.Policies)
+
+	if err != nil {
+		return nil, err
+	}
+
+	p.merge(&Policy{Params: c.Checks})
+
+	return p, p.Validate()
+}
+
+// GetPlugins returns plugins from configuration and all plugins found in PATH
+func (c *Config) GetPlugins() ([]*Plugin, error) {
+	return FindPlugins(c.Plugins, time.Duration(c.PluginTimeout)*time.Second)
+}
@@ -0,0 +1,161 @@
+package check
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/essentialkaos/perfecto/spec"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// deprecation contains info about obsolete spec construct
+type deprecation struct {
+	Name        string         // Unique name of catalog entry
+	Construct   string         // Obsolete construct
+	Replacement string         // Replacement or recommended action
+	Since       string         // RPM version or distribution where construct became obsolete
+	Sections    []string       // Sections where construct is searched (all if empty)
+	Pattern     *regexp.Regexp // Construct pattern
+	Level       uint8          // Default alert level
+	OnlyFirst   bool           // Check only the first meaningful line of section
+	IsOptional  bool           // Entry is disabled by default
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// deprecations is catalog of obsolete constructs
+var deprecations = []*deprecation{
+	{
+		Name:        "buildroot-tag",
+		Construct:   "BuildRoot tag",
+		Replacement: "remove it, build root is set by RPM automatically",
+		Since:       "RPM 4.6",
+		Pattern:     regexp.MustCompile(`^BuildRoot\s*:`),
+		Level:       LEVEL_NOTICE,
+		IsOptional:  true,
+	},
+	{
+		Name:        "prereq-tag",
+		Construct:   "PreReq tag",
+		Replacement: "use Requires(pre) and Requires(post) instead",
+		Since:       "RPM 4.6",
+		Pattern:     regexp.MustCompile(`^PreReq\s*:`),
+		Level:       LEVEL_WARNING,
+	},
+	{
+		Name:        "copyright-tag",
+		Construct:   "Copyright tag",
+		Replacement: "use License tag instead",
+		Since:       "RPM 4.0",
+		Pattern:     regexp.MustCompile(`^Copyright\s*:`),
+		Level:       LEVEL_ERROR,
+	},
+	{
+		Name:        "makeinstall-macro",
+		Construct:   "%makeinstall macro",
+		Replacement: "use %make_install instead",
+		Since:       "RPM 4.10",
+		Pattern:     regexp.MustCompile(`%\{?makeinstall\b`),
+		Level:       LEVEL_WARNING,
+	},
+	{
+		Name:        "python-macro",
+		Construct:   "%{__python} macro",
+		Replacement: "use %{__python3} instead",
+		Since:       "EL8",
+		Pattern:     regexp.MustCompile(`%\{?__python\b`),
+		Level:       LEVEL_WARNING,
+	},
+	{
+		Name:        "py-requires-macro",
+		Construct:   "%py_requires macro",
+		Replacement: "add BuildRequires: python3-devel instead",
+		Since:       "EL8",
+		Pattern:     regexp.MustCompile(`%\{?py_requires\b`),
+		Level:       LEVEL_WARNING,
+	},
+	{
+		Name:        "clean-section",
+		Construct:   "%clean section with rm -rf",
+		Replacement: "remove it, build root is cleaned by RPM automatically",
+		Since:       "RPM 4.6",
+		Sections:    []string{spec.SECTION_CLEAN},
+		Pattern:     regexp.MustCompile(`^rm\s+-rf\s`),
+		Level:       LEVEL_NOTICE,
+		IsOptional:  true,
+	},
+	{
+		Name:        "install-cleanup",
+		Construct:   "Build root removal at the beginning of %install",
+		Replacement: "remove it, build root is cleaned by RPM automatically",
+		Since:       "RPM 4.6",
+		Sections:    []string{spec.SECTION_INSTALL},
+		Pattern:     regexp.MustCompile(`^rm\s+-rf\s+(%\{?buildroot\}?|\$\{?RPM_BUILD_ROOT\}?)/?$`),
+		Level:       LEVEL_NOTICE,
+		OnlyFirst:   true,
+		IsOptional:  true,
+	},
+	{
+		Name:        "smp-mflags-macro",
+		Construct:   "%{?_smp_mflags} macro",
+		Replacement: "use %make_build or %{?_smp_build_ncpus} instead",
+		Since:       "RPM 4.15",
+		Pattern:     regexp.MustCompile(`%\{\??_smp_mflags\}`),
+		Level:       LEVEL_NOTICE,
+		IsOptional:  true,
+	},
+	{
+		Name:        "patch-number",
+		Construct:   "%patchN syntax",
+		Replacement: "use %patch -P N instead",
+		Since:       "RPM 4.20",
+		Sections:    []string{spec.SECTION_PREP, spec.SECTION_SETUP},
+		Pattern:     regexp.MustCompile(`^%patch[0-9]+`),
+		Level:       LEVEL_ERROR,
+	},
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Find returns lines which contain obsolete construct
+func (d *deprecation) Find(s *spec.Spec, skipLines map[int]bool) []spec.Line {
+	var result []spec.Line
+
+	blocks := [][]spec.Line{s.Data}
+
+	if len(d.Sections) != 0 {
+		blocks = nil
+
+		for _, section := range s.GetSections(d.Sections...) {
+			blocks = append(blocks, section.Data)
+		}
+	}
+
+	for _, data := range blocks {
+		for _, line := range data {
+			text := strings.TrimSpace(line.Text)
+
+			if skipLines[line.Index] || text == "" || isComment(line) {
+				continue
+			}
+
+			if d.Pattern.MatchString(text) {
+				result = append(result, line)
+			}
+
+			if d.OnlyFirst {
+				break
+			}
+		}
+	}
+
+	return result
+}
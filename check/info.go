@@ -0,0 +1,153 @@
+package check
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// CheckInfo contains basic info about check
+type CheckInfo struct {
+	ID         string `json:"id"`
+	Group      string `json:"group"`
+	Desc       string `json:"desc"`
+	IsDisabled bool   `json:"is_disabled"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// checksDesc contains descriptions of built-in checks
+var checksDesc = map[string]string{
+	"PF1":  "Useless spaces",
+	"PF2":  "Line length in changelog and descriptions",
+	"PF3":  "Dist macro in Release tag",
+	"PF4":  "Standard paths used without macros",
+	"PF5":  "Variables used instead of macros",
+	"PF6":  "Format of /dev/null redirects",
+	"PF7":  "Misformatted changelog records",
+	"PF8":  "Make used not as macro",
+	"PF9":  "Required header tags",
+	"PF10": "Unescaped percent symbol in changelog and descriptions",
+	"PF11": "Macro defined after description",
+	"PF12": "Separator length",
+	"PF13": "%defattr macro in %files sections",
+	"PF14": "Useless binary macro",
+	"PF15": "Empty sections",
+	"PF16": "Indentation in %files sections",
+	"PF17": "Arguments of %setup macro",
+	"PF18": "Empty lines at the end of spec",
+	"PF19": "Format of bash loops",
+	"PF20": "HTTPS support of source domains",
+	"PF21": "Macro for skipping %check section",
+	"PF22": "Single equals symbol in if clauses",
+	"PF23": "Useless slash after %{buildroot} macro",
+	"PF24": "Empty if clauses",
+	"PF25": "Dot at the end of summary",
+	"PF26": "chown and chmod commands in scriptlets",
+	"PF27": "Unclosed conditions",
+	"PF28": "Summary tag length",
+	"PF29": "SPDX expression in License tag",
+	"PF30": "Systemd scriptlets macros and raw systemctl calls",
+	"PF31": "Imperative user and group creation in %pre scriptlets",
+	"PF32": "Scriptlets arguments checks and failing commands",
+	"PF33": "Shell syntax errors in build sections and scriptlets",
+	"PF34": "Shared libraries scriptlets and development files",
+	"PF35": "Undefined, unused and redefined macros",
+	"PF36": "Build conditions declarations and tests",
+	"PF37": "Deprecated syntax",
+	"PF38": "Network access in build sections",
+	"PF39": "Source URLs formats",
+
+	"GO1": "Raw go commands instead of go-rpm-macros",
+	"PY1": "Raw pip and setup.py commands instead of pyproject-rpm-macros",
+	"PY2": "Build dependencies not generated by %pyproject_buildrequires",
+	"PY3": "Import check in %check section",
+	"RS1": "Raw cargo commands instead of cargo-rpm-macros",
+	"NJ1": "Raw npm commands instead of nodejs-packaging macros",
+	"NJ2": "Hardcoded path to node modules directory",
+
+	RPMLINT_CHECK_ID: "RPMLint errors",
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// GetChecks returns info about all available checks including custom rules and
+// plugins checks. Errors of plugins are returned as a second value.
+func GetChecks() ([]CheckInfo, []error) {
+	var result []CheckInfo
+	var errs []error
+
+	for _, id := range sortIDs(getCheckers()) {
+		result = append(result, newCheckInfo(id, "Built-in", checksDesc[id]))
+	}
+
+	result = append(result, newCheckInfo(RPMLINT_CHECK_ID, "Built-in", checksDesc[RPMLINT_CHECK_ID]))
+
+	for _, pack := range getRulePacks() {
+		for _, id := range sortIDs(pack.Checkers) {
+			result = append(result, newCheckInfo(id, pack.Name, checksDesc[id]))
+		}
+	}
+
+	for _, rule := range rules {
+		result = append(result, newCheckInfo(rule.ID, "Custom rules", rule.Message))
+	}
+
+	for _, p := range plugins {
+		checks, err := p.Checks()
+
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		for _, c := range checks {
+			result = append(result, newCheckInfo(c.ID, "Plugin "+p.Name, c.Desc))
+		}
+	}
+
+	return result, errs
+}
+
+// IsBuiltIn returns true if check with given ID is built-in check
+func IsBuiltIn(id string) bool {
+	_, ok := checksDesc[id]
+	return ok
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// newCheckInfo creates new check info
+func newCheckInfo(id, group, desc string) CheckInfo {
+	return CheckInfo{id, group, desc, policy.IsDisabled(id)}
+}
+
+// sortIDs returns sorted slice with checks IDs
+func sortIDs(checkers map[string]Checker) []string {
+	var result []string
+
+	for id := range checkers {
+		result = append(result, id)
+	}
+
+	slices.SortFunc(result, func(a, b string) int {
+		return getIDNum(a) - getIDNum(b)
+	})
+
+	return result
+}
+
+// getIDNum returns numeric part of check ID
+func getIDNum(id string) int {
+	num, _ := strconv.Atoi(strings.TrimLeft(id, "ABCDEFGHIJKLMNOPQRSTUVWXYZ"))
+	return num
+}
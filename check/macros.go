@@ -0,0 +1,280 @@
+package check
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	_ "embed"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/strutil"
+
+	"github.com/essentialkaos/perfecto/spec"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+//go:embed data/rpm-macros.txt
+var rpmMacrosData string
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// macroDef contains info about macro definition
+type macroDef struct {
+	Name       string
+	Line       spec.Line
+	Branch     string
+	IsDefine   bool
+	IsBcond    bool
+	IsTopLevel bool
+}
+
+// macroUsage contains info about macro usage
+type macroUsage struct {
+	Name        string
+	Raw         string
+	Line        spec.Line
+	IsOptional  bool
+	IsBcondTest bool
+}
+
+// macroTable contains info about all macro definitions and usages in spec
+type macroTable struct {
+	Defs   []macroDef
+	Usages []macroUsage
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// rpmMacros is set with names of built-in RPM macros
+var rpmMacros = parseMacroList(rpmMacrosData)
+
+// printfFormatRegExp is regexp for printf format specifiers (%05d, %ld)
+var printfFormatRegExp = regexp.MustCompile(`^[0-9]*(hh|h|ll|l|L|q|j|z|t)?[diouxXeEfFgGcsaA]$`)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// buildMacroTable collects all macro definitions and usages from spec
+func buildMacroTable(s *spec.Spec) *macroTable {
+	table := &macroTable{}
+	skipLines := make(map[int]bool)
+	sectionLines := make(map[int]bool)
+
+	for _, section := range s.GetSections() {
+		if section.Name == spec.SECTION_PACKAGE {
+			continue
+		}
+
+		for _, line := range section.Data {
+			sectionLines[line.Index] = true
+			skipLines[line.Index] = section.Name == spec.SECTION_CHANGELOG
+		}
+	}
+
+	var branches []string
+
+	for _, line := range s.Data {
+		if skipLines[line.Index] || isComment(line) {
+			continue
+		}
+
+		fields := strutil.Fields(strings.ReplaceAll(strings.TrimSpace(line.Text), "\t", " "))
+
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "%if", "%ifarch", "%ifnarch", "%ifos", "%ifnos":
+			branches = append(branches, fmt.Sprintf("%d", line.Index))
+		case "%else", "%elif", "%elifarch", "%elifos":
+			if len(branches) != 0 {
+				branches[len(branches)-1] = fmt.Sprintf("%d", line.Index)
+			}
+		case "%endif":
+			if len(branches) != 0 {
+				branches = branches[:len(branches)-1]
+			}
+		case "%undefine":
+			continue
+		case "%define", "%global", "%bcond", "%bcond_with", "%bcond_without":
+			if len(fields) < 2 {
+				break
+			}
+
+			isBcond := strings.HasPrefix(fields[0], "%bcond")
+			name, _, _ := strings.Cut(fields[1], "(")
+
+			if isBcond {
+				name = "with_" + name
+			}
+
+			table.Defs = append(table.Defs, macroDef{
+				Name:       name,
+				Line:       line,
+				Branch:     strings.Join(branches, "/"),
+				IsDefine:   fields[0] == "%define",
+				IsBcond:    isBcond,
+				IsTopLevel: !sectionLines[line.Index],
+			})
+
+			if !isBcond {
+				_, body, _ := strings.Cut(line.Text, fields[1])
+				table.Usages = append(table.Usages, findMacroUsages(line, body)...)
+			}
+
+			continue
+		}
+
+		table.Usages = append(table.Usages, findMacroUsages(line, line.Text)...)
+	}
+
+	return table
+}
+
+// IsDefined returns true if macro with given name is defined in spec
+func (t *macroTable) IsDefined(name string) bool {
+	for _, def := range t.Defs {
+		if def.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsUsed returns true if macro with given name is used in spec
+func (t *macroTable) IsUsed(name string) bool {
+	for _, usage := range t.Usages {
+		if usage.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// findMacroUsages finds all macro usages in given text
+func findMacroUsages(line spec.Line, text string) []macroUsage {
+	var result []macroUsage
+
+	for i := 0; i < len(text); i++ {
+		if text[i] != '%' || i+1 >= len(text) {
+			continue
+		}
+
+		if text[i+1] == '%' {
+			i++
+			continue
+		}
+
+		j := i + 1
+		isBraced := text[j] == '{'
+
+		if isBraced {
+			j++
+		}
+
+		isOptional := false
+
+		for j < len(text) && (text[j] == '?' || text[j] == '!') {
+			isOptional = isOptional || text[j] == '?'
+			j++
+		}
+
+		start := j
+
+		for j < len(text) && isMacroIdentChar(text[j]) {
+			j++
+		}
+
+		name := text[start:j]
+		i = j - 1
+
+		if name == "" || (!isBraced && printfFormatRegExp.MatchString(name)) {
+			continue
+		}
+
+		switch name {
+		case "with", "without", "defined", "undefined":
+			if !isBraced || j >= len(text) || text[j] != ' ' {
+				break
+			}
+
+			arg, _, _ := strings.Cut(text[j+1:], "}")
+			arg = strings.TrimSpace(arg)
+
+			isBcondTest := name == "with" || name == "without"
+
+			if isBcondTest {
+				arg = "with_" + arg
+			}
+
+			result = append(result, macroUsage{normalizeMacroName(arg), name, line, true, isBcondTest})
+			continue
+		}
+
+		result = append(result, macroUsage{normalizeMacroName(name), name, line, isOptional, false})
+	}
+
+	return result
+}
+
+// normalizeMacroName converts names of bcond macros to the same form
+func normalizeMacroName(name string) string {
+	for _, p := range []string{"_with_", "_without_", "without_"} {
+		if strings.HasPrefix(name, p) {
+			return "with_" + strings.TrimPrefix(name, p)
+		}
+	}
+
+	return name
+}
+
+// isBuiltinMacro returns true if macro with given name is defined by RPM
+func isBuiltinMacro(name string) bool {
+	if rpmMacros[name] || strings.HasPrefix(name, "__") || len(name) == 1 {
+		return true
+	}
+
+	for _, tag := range []string{"SOURCE", "PATCH", "patch"} {
+		if strings.HasPrefix(name, tag) && strings.Trim(name[len(tag):], "0123456789") == "" {
+			return true
+		}
+	}
+
+	return strings.Trim(name, "0123456789") == ""
+}
+
+// isMacroIdentChar returns true if given char can be used in macro identifier
+func isMacroIdentChar(c byte) bool {
+	return c == '_' ||
+		(c >= 'a' && c <= 'z') ||
+		(c >= 'A' && c <= 'Z') ||
+		(c >= '0' && c <= '9')
+}
+
+// parseMacroList parses list with macro names
+func parseMacroList(data string) map[string]bool {
+	result := make(map[string]bool)
+
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		result[line] = true
+	}
+
+	return result
+}
@@ -0,0 +1,284 @@
+package check
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/strutil"
+
+	"github.com/essentialkaos/perfecto/spec"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// rulePack contains optional checks for specific language ecosystem
+type rulePack struct {
+	Name     string             // Ecosystem name
+	Prefix   string             // Prefix of checks IDs
+	Packages []string           // BuildRequires which activate pack
+	Macros   []string           // Prefixes of macros which activate pack
+	Checkers map[string]Checker // Pack checks
+}
+
+// rawCommand contains info about command which must be replaced by macro
+type rawCommand struct {
+	Command  string   // Command with subcommand
+	Macro    string   // Macro which must be used instead of command
+	Sections []string // Sections where command is searched
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// goCommands contains Go commands which must be replaced by macros
+var goCommands = []rawCommand{
+	{"go build", "%gobuild", []string{spec.SECTION_BUILD}},
+	{"go test", "%gotest", []string{spec.SECTION_CHECK}},
+}
+
+// pythonCommands contains Python commands which must be replaced by macros
+var pythonCommands = []rawCommand{
+	{"pip wheel", "%pyproject_wheel", []string{spec.SECTION_BUILD}},
+	{"pip install", "%pyproject_wheel", []string{spec.SECTION_BUILD}},
+	{"pip install", "%pyproject_install", []string{spec.SECTION_INSTALL}},
+	{"pip3 install", "%pyproject_wheel", []string{spec.SECTION_BUILD}},
+	{"pip3 install", "%pyproject_install", []string{spec.SECTION_INSTALL}},
+	{"setup.py build", "%pyproject_wheel", []string{spec.SECTION_BUILD}},
+	{"setup.py install", "%pyproject_install", []string{spec.SECTION_INSTALL}},
+}
+
+// rustCommands contains Rust commands which must be replaced by macros
+var rustCommands = []rawCommand{
+	{"cargo build", "%cargo_build", []string{spec.SECTION_BUILD}},
+	{"cargo install", "%cargo_install", []string{spec.SECTION_INSTALL}},
+	{"cargo test", "%cargo_test", []string{spec.SECTION_CHECK}},
+}
+
+// nodeCommands contains Node.js commands which must be replaced by macros
+var nodeCommands = []rawCommand{
+	{"npm install", "%nodejs_symlink_deps", []string{spec.SECTION_BUILD, spec.SECTION_INSTALL}},
+	{"npm ci", "%nodejs_symlink_deps", []string{spec.SECTION_BUILD, spec.SECTION_INSTALL}},
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getRulePacks returns slice with all rule packs
+func getRulePacks() []*rulePack {
+	return []*rulePack{
+		{
+			Name:     "Go",
+			Prefix:   "GO",
+			Packages: []string{"go-rpm-macros"},
+			Macros:   []string{"gometa", "goprep", "gobuild", "gopkg", "gotest", "gocheck"},
+			Checkers: map[string]Checker{
+				"GO1": checkGoCommands,
+			},
+		},
+		{
+			Name:     "Python",
+			Prefix:   "PY",
+			Packages: []string{"pyproject-rpm-macros"},
+			Macros:   []string{"pyproject_", "py3_check_import"},
+			Checkers: map[string]Checker{
+				"PY1": checkPythonCommands,
+				"PY2": checkPythonBuildRequires,
+				"PY3": checkPythonImportCheck,
+			},
+		},
+		{
+			Name:     "Rust",
+			Prefix:   "RS",
+			Packages: []string{"cargo-rpm-macros", "rust-packaging"},
+			Macros:   []string{"cargo_"},
+			Checkers: map[string]Checker{
+				"RS1": checkRustCommands,
+			},
+		},
+		{
+			Name:     "Node.js",
+			Prefix:   "NJ",
+			Packages: []string{"nodejs-packaging"},
+			Macros:   []string{"nodejs_"},
+			Checkers: map[string]Checker{
+				"NJ1": checkNodeCommands,
+				"NJ2": checkNodeModulesPath,
+			},
+		},
+	}
+}
+
+// IsActive returns true if pack must be used for given spec
+func (p *rulePack) IsActive(s *spec.Spec) bool {
+	if hasBuildRequires(s, p.Packages...) {
+		return true
+	}
+
+	for _, line := range s.Data {
+		if isComment(line) {
+			continue
+		}
+
+		for _, found := range macroRegExp.FindAllStringSubmatch(line.Text, -1) {
+			if strutil.HasPrefixAny(found[1], p.Macros...) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// checkGoCommands checks for raw go commands instead of go-rpm-macros
+func checkGoCommands(id string, s *spec.Spec) []Alert {
+	return findRawCommands(id, s, goCommands)
+}
+
+// checkPythonCommands checks for raw pip and setup.py commands instead of
+// pyproject-rpm-macros
+func checkPythonCommands(id string, s *spec.Spec) []Alert {
+	return findRawCommands(id, s, pythonCommands)
+}
+
+// checkPythonBuildRequires checks that build dependencies are generated
+// by %pyproject_buildrequires
+func checkPythonBuildRequires(id string, s *spec.Spec) []Alert {
+	if len(s.Data) == 0 || !hasMacro(s.Data, "pyproject_wheel") {
+		return nil
+	}
+
+	sections := s.GetSections(spec.SECTION_GENERATE_BUILDREQUIRES)
+
+	if len(sections) == 0 {
+		return []Alert{NewAlert(id, LEVEL_WARNING, "Use %pyproject_buildrequires in %generate_buildrequires section for generating build dependencies", emptyLine)}
+	}
+
+	if !hasMacro(sections[0].Data, "pyproject_buildrequires") {
+		return []Alert{NewAlert(id, LEVEL_WARNING, "Section %generate_buildrequires doesn't contain %pyproject_buildrequires", s.Data[sections[0].Start-1])}
+	}
+
+	return nil
+}
+
+// checkPythonImportCheck checks that %check section contains import check
+func checkPythonImportCheck(id string, s *spec.Spec) []Alert {
+	if len(s.Data) == 0 {
+		return nil
+	}
+
+	sections := s.GetSections(spec.SECTION_CHECK)
+
+	if len(sections) == 0 {
+		return []Alert{NewAlert(id, LEVEL_NOTICE, "Add %check section with %py3_check_import for checking module import", emptyLine)}
+	}
+
+	for _, macro := range []string{"py3_check_import", "pyproject_check_import", "pytest", "tox"} {
+		if hasMacro(sections[0].Data, macro) {
+			return nil
+		}
+	}
+
+	return []Alert{NewAlert(id, LEVEL_NOTICE, "Use %py3_check_import in %check section for checking module import", s.Data[sections[0].Start-1])}
+}
+
+// checkRustCommands checks for raw cargo commands instead of cargo-rpm-macros
+func checkRustCommands(id string, s *spec.Spec) []Alert {
+	return findRawCommands(id, s, rustCommands)
+}
+
+// checkNodeCommands checks for raw npm commands instead of nodejs-packaging
+// macros
+func checkNodeCommands(id string, s *spec.Spec) []Alert {
+	return findRawCommands(id, s, nodeCommands)
+}
+
+// checkNodeModulesPath checks for hardcoded path to node modules directory
+func checkNodeModulesPath(id string, s *spec.Spec) []Alert {
+	if len(s.Data) == 0 {
+		return nil
+	}
+
+	var result []Alert
+
+	for _, section := range s.GetSections(spec.SECTION_INSTALL, spec.SECTION_FILES) {
+		for _, line := range section.Data {
+			if isComment(line) {
+				continue
+			}
+
+			if contains(line, "/usr/lib/node_modules") || contains(line, "%{_prefix}/lib/node_modules") {
+				result = append(result, NewAlert(id, LEVEL_WARNING, "Use %{nodejs_sitelib} macro instead of hardcoded path to node modules directory", line))
+			}
+		}
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// findRawCommands finds commands which must be replaced by macros
+func findRawCommands(id string, s *spec.Spec, commands []rawCommand) []Alert {
+	if len(s.Data) == 0 {
+		return nil
+	}
+
+	var result []Alert
+
+	for _, section := range s.GetSections() {
+		for _, line := range section.Data {
+			if isComment(line) {
+				continue
+			}
+
+			for _, cmd := range commands {
+				if !slices.Contains(cmd.Sections, section.Name) || !hasCommand(line, cmd.Command) {
+					continue
+				}
+
+				desc := fmt.Sprintf("Use %s macro instead of \"%s\" command", cmd.Macro, cmd.Command)
+				result = append(result, NewAlert(id, LEVEL_WARNING, desc, line))
+
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// hasCommand returns true if line contains given command with subcommand
+func hasCommand(line spec.Line, command string) bool {
+	binary, subcommand, _ := strings.Cut(command, " ")
+	fields := strutil.Fields(strings.ReplaceAll(line.Text, "\t", " "))
+
+	for i, field := range fields[:max(len(fields)-1, 0)] {
+		field = strings.TrimSuffix(strings.TrimPrefix(field, "%{__"), "}")
+		field = field[strings.LastIndex(field, "/")+1:]
+
+		if field == binary && fields[i+1] == subcommand {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasMacro returns true if any of given lines contains given macro
+func hasMacro(data []spec.Line, macro string) bool {
+	for _, line := range data {
+		if !isComment(line) && containsMacro(line, macro) {
+			return true
+		}
+	}
+
+	return false
+}
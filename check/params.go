@@ -0,0 +1,238 @@
+package check
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Parameter types
+const (
+	PARAM_INT     uint8 = iota + 1 // Integer number
+	PARAM_BOOL                     // Boolean flag
+	PARAM_STRINGS                  // List of strings
+	PARAM_PAIRS                    // List of string pairs
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Param contains info about checker parameter
+type Param struct {
+	Name    string // Parameter name
+	Type    uint8  // Parameter type
+	Default any    // Default value
+	Desc    string // Description
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// checkParams contains parameters declared by checkers
+var checkParams = map[string][]Param{
+	"PF2": {
+		{"max_length", PARAM_INT, 80, "Maximum length of lines in %description and %changelog"},
+	},
+	"PF4": {
+		{"paths", PARAM_PAIRS, getPathMacroPairs(), "Paths and macros which must be used instead of them"},
+	},
+	"PF9": {
+		{"require_group", PARAM_BOOL, true, "Require Group tag in all packages"},
+	},
+	"PF12": {
+		{"length", PARAM_INT, 80, "Length of separators"},
+	},
+	"PF14": {
+		{"binaries", PARAM_STRINGS, binariesAsMacro, "Binaries which must not be executed using macro"},
+	},
+	"PF28": {
+		{"limit", PARAM_INT, 70, "Minimal length of too long summary"},
+	},
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// GetParams returns parameters declared by check with given ID
+func GetParams(id string) []Param {
+	return checkParams[id]
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// GetInt returns integer parameter of check
+func (p *Policy) GetInt(id, name string) int {
+	v, _ := p.getParam(id, name).(int)
+	return v
+}
+
+// GetBool returns boolean parameter of check
+func (p *Policy) GetBool(id, name string) bool {
+	v, _ := p.getParam(id, name).(bool)
+	return v
+}
+
+// GetStrings returns list parameter of check
+func (p *Policy) GetStrings(id, name string) []string {
+	v, _ := p.getParam(id, name).([]string)
+	return v
+}
+
+// GetPairs returns list of pairs parameter of check
+func (p *Policy) GetPairs(id, name string) [][2]string {
+	v, _ := p.getParam(id, name).([][2]string)
+	return v
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getParam returns value of parameter or its default value
+func (p *Policy) getParam(id, name string) any {
+	value, ok := p.Params[id][name]
+
+	if ok {
+		return value
+	}
+
+	index := slices.IndexFunc(checkParams[id], func(param Param) bool {
+		return param.Name == name
+	})
+
+	if index == -1 {
+		return nil
+	}
+
+	return checkParams[id][index].Default
+}
+
+// validateParams validates parameters and converts them to declared types
+func (p *Policy) validateParams() error {
+	for id, params := range p.Params {
+		declared, ok := checkParams[id]
+
+		if !ok {
+			return fmt.Errorf("Check %s doesn't have parameters", id)
+		}
+
+		for name, value := range params {
+			index := slices.IndexFunc(declared, func(param Param) bool {
+				return param.Name == name
+			})
+
+			if index == -1 {
+				return fmt.Errorf("Unknown parameter %q for check %s", name, id)
+			}
+
+			value, ok := convertParam(value, declared[index].Type)
+
+			if !ok {
+				return fmt.Errorf(
+					"Parameter %q for check %s must be %s",
+					name, id, getParamTypeName(declared[index].Type),
+				)
+			}
+
+			if declared[index].Type == PARAM_INT && value.(int) <= 0 {
+				return fmt.Errorf(
+					"Parameter %q for check %s must be a positive integer",
+					name, id,
+				)
+			}
+
+			params[name] = value
+		}
+	}
+
+	return nil
+}
+
+// convertParam converts parameter value to given type
+func convertParam(value any, typ uint8) (any, bool) {
+	switch typ {
+	case PARAM_INT:
+		switch v := value.(type) {
+		case int:
+			return v, true
+		case int64:
+			return int(v), true
+		}
+
+	case PARAM_BOOL:
+		v, ok := value.(bool)
+		return v, ok
+
+	case PARAM_STRINGS:
+		switch v := value.(type) {
+		case []string:
+			return v, true
+		case []any:
+			var result []string
+
+			for _, item := range v {
+				str, ok := item.(string)
+
+				if !ok {
+					return nil, false
+				}
+
+				result = append(result, str)
+			}
+
+			return result, true
+		}
+
+	case PARAM_PAIRS:
+		switch v := value.(type) {
+		case [][2]string:
+			return v, true
+		case []any:
+			var result [][2]string
+
+			for _, item := range v {
+				pair, ok := convertParam(item, PARAM_STRINGS)
+
+				if !ok || len(pair.([]string)) != 2 {
+					return nil, false
+				}
+
+				result = append(result, [2]string(pair.([]string)))
+			}
+
+			return result, true
+		}
+	}
+
+	return nil, false
+}
+
+// getParamTypeName returns name of parameter type
+func getParamTypeName(typ uint8) string {
+	switch typ {
+	case PARAM_INT:
+		return "an integer"
+	case PARAM_BOOL:
+		return "a boolean"
+	case PARAM_STRINGS:
+		return "a list of strings"
+	case PARAM_PAIRS:
+		return "a list of string pairs"
+	}
+
+	return "unknown"
+}
+
+// getPathMacroPairs returns paths and macros from pathMacroSlice as pairs
+func getPathMacroPairs() [][2]string {
+	var result [][2]string
+
+	for _, m := range pathMacroSlice {
+		result = append(result, [2]string{m.Value, m.Name})
+	}
+
+	return result
+}
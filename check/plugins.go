@@ -0,0 +1,256 @@
+package check
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/perfecto/spec"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	// PLUGIN_PREFIX is prefix of plugins executables names
+	PLUGIN_PREFIX = "perfecto-plugin-"
+
+	// PLUGIN_SEPARATOR is separator between plugin name and ID of plugin check
+	PLUGIN_SEPARATOR = "/"
+
+	// DEFAULT_PLUGIN_TIMEOUT is default timeout for plugin execution
+	DEFAULT_PLUGIN_TIMEOUT = 10 * time.Second
+)
+
+// Plugin commands
+const (
+	PLUGIN_CMD_CHECK = "check"
+	PLUGIN_CMD_LIST  = "list"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Plugin is external executable with custom checks
+type Plugin struct {
+	Name    string        // Plugin name used as prefix of checks IDs
+	Path    string        // Path to executable
+	Timeout time.Duration // Execution timeout
+}
+
+// PluginCheck contains info about plugin check
+type PluginCheck struct {
+	ID   string `json:"id"`
+	Desc string `json:"desc"`
+}
+
+// PluginAlert contains alert returned by plugin
+type PluginAlert struct {
+	ID        string `json:"id"`
+	Level     string `json:"level"`
+	Info      string `json:"info"`
+	Line      int    `json:"line"`
+	Fix       string `json:"fix,omitempty"`
+	Column    int    `json:"column,omitempty"`
+	EndColumn int    `json:"end_column,omitempty"`
+	EndLine   int    `json:"end_line,omitempty"`
+}
+
+// pluginResponse contains plugin output
+type pluginResponse struct {
+	Checks []PluginCheck `json:"checks"`
+	Alerts []PluginAlert `json:"alerts"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// plugins contains used plugins
+var plugins []*Plugin
+
+// pluginNameRegExp is regexp for plugins names
+var pluginNameRegExp = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SetPlugins sets plugins used for checking specs
+func SetPlugins(list []*Plugin) {
+	plugins = list
+}
+
+// FindPlugins returns plugins with given paths and all plugins found in
+// directories from PATH environment variable. Plugins with given paths take
+// precedence over plugins with the same name from PATH.
+func FindPlugins(paths []string, timeout time.Duration) ([]*Plugin, error) {
+	var result []*Plugin
+
+	names := map[string]bool{}
+
+	for _, path := range paths {
+		p, err := NewPlugin(path, timeout)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if names[p.Name] {
+			return nil, fmt.Errorf("Plugin %q is defined more than once", p.Name)
+		}
+
+		names[p.Name] = true
+		result = append(result, p)
+	}
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		files, _ := filepath.Glob(filepath.Join(dir, PLUGIN_PREFIX+"*"))
+
+		for _, file := range files {
+			p, err := NewPlugin(file, timeout)
+
+			if err != nil || names[p.Name] {
+				continue
+			}
+
+			names[p.Name] = true
+			result = append(result, p)
+		}
+	}
+
+	return result, nil
+}
+
+// NewPlugin creates new plugin for executable with given path
+func NewPlugin(path string, timeout time.Duration) (*Plugin, error) {
+	info, err := os.Stat(path)
+
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("Can't use plugin %s: %w", path, err)
+	case !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0:
+		return nil, fmt.Errorf("Can't use plugin %s: file is not executable", path)
+	}
+
+	name := strings.TrimPrefix(filepath.Base(path), PLUGIN_PREFIX)
+
+	if !pluginNameRegExp.MatchString(name) {
+		return nil, fmt.Errorf("Can't use plugin %s: name %q is invalid", path, name)
+	}
+
+	if timeout <= 0 {
+		timeout = DEFAULT_PLUGIN_TIMEOUT
+	}
+
+	return &Plugin{Name: name, Path: path, Timeout: timeout}, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Check runs plugin checks. Plugin errors are returned as an alert, so
+// one broken plugin doesn't affect other checks.
+func (p *Plugin) Check(id string, s *spec.Spec) []Alert {
+	input, err := json.Marshal(s)
+
+	if err != nil {
+		return []Alert{p.errorAlert(id, err)}
+	}
+
+	resp, err := p.exec(PLUGIN_CMD_CHECK, input)
+
+	if err != nil {
+		return []Alert{p.errorAlert(id, err)}
+	}
+
+	var result []Alert
+
+	for _, pa := range resp.Alerts {
+		level, ok := parseLevel(pa.Level)
+
+		switch {
+		case pa.ID == "":
+			return []Alert{p.errorAlert(id, errors.New("alert without ID"))}
+		case !ok:
+			return []Alert{p.errorAlert(id, fmt.Errorf("unknown alert level %q", pa.Level))}
+		}
+
+		alert := NewAlertWithFix(
+			id+PLUGIN_SEPARATOR+pa.ID, level, pa.Info, s.GetLine(pa.Line), pa.Fix,
+		).WithEndLine(s.GetLine(pa.EndLine))
+
+		if pa.Column > 0 && pa.EndColumn >= pa.Column {
+			alert.Column, alert.EndColumn = pa.Column, pa.EndColumn
+		}
+
+		result = append(result, alert)
+	}
+
+	return result
+}
+
+// Checks returns info about plugin checks
+func (p *Plugin) Checks() ([]PluginCheck, error) {
+	resp, err := p.exec(PLUGIN_CMD_LIST, nil)
+
+	if err != nil {
+		return nil, fmt.Errorf("Plugin %s failed: %w", p.Name, err)
+	}
+
+	for i := range resp.Checks {
+		resp.Checks[i].ID = p.Name + PLUGIN_SEPARATOR + resp.Checks[i].ID
+	}
+
+	return resp.Checks, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// exec executes plugin with given command and decodes its output
+func (p *Plugin) exec(command string, input []byte) (*pluginResponse, error) {
+	var stdout, stderr bytes.Buffer
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, p.Path, command)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		return nil, fmt.Errorf("timeout (%v) exceeded", p.Timeout)
+	case err != nil && stderr.Len() != 0:
+		msg, _, _ := strings.Cut(strings.TrimSpace(stderr.String()), "\n")
+		return nil, fmt.Errorf("%w (%s)", err, msg)
+	case err != nil:
+		return nil, err
+	}
+
+	resp := &pluginResponse{}
+	err = json.Unmarshal(stdout.Bytes(), resp)
+
+	if err != nil {
+		return nil, fmt.Errorf("can't decode output: %w", err)
+	}
+
+	return resp, nil
+}
+
+// errorAlert creates alert about plugin error
+func (p *Plugin) errorAlert(id string, err error) Alert {
+	return NewAlert(id, LEVEL_ERROR, fmt.Sprintf("Plugin %s failed: %v", p.Name, err), emptyLine)
+}
@@ -0,0 +1,216 @@
+package check
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Built-in policies
+const (
+	POLICY_KAOS     = "kaos"
+	POLICY_FEDORA   = "fedora"
+	POLICY_EPEL     = "epel"
+	POLICY_OPENSUSE = "opensuse"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Policy contains configuration of checks
+type Policy struct {
+	Name         string                    `toml:"-"`
+	Extends      string                    `toml:"extends"`      // Name of parent policy
+	Disabled     []string                  `toml:"disabled"`     // IDs or groups of disabled checks
+	Enabled      []string                  `toml:"enabled"`      // IDs or groups of checks disabled by parent policy
+	Deprecations []string                  `toml:"deprecations"` // Names of enabled optional deprecations
+	Levels       map[string]string         `toml:"levels"`       // Alert levels of checks and deprecations
+	Params       map[string]map[string]any `toml:"params"`       // Parameters of checks
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// policies contains built-in policies
+var policies = map[string]*Policy{
+	POLICY_KAOS: {},
+	POLICY_FEDORA: {
+		Disabled:     []string{"PF12", "PF13", "PF21"},
+		Deprecations: []string{"buildroot-tag", "clean-section", "install-cleanup"},
+		Levels:       map[string]string{"PF29": "error"},
+		Params: map[string]map[string]any{
+			"PF9": {"require_group": false},
+		},
+	},
+	POLICY_EPEL: {
+		Extends: POLICY_FEDORA,
+		Levels:  map[string]string{"PF29": "warning"},
+	},
+	POLICY_OPENSUSE: {
+		Disabled:     []string{"PF12", "PF13", "PF21"},
+		Deprecations: []string{"buildroot-tag", "clean-section"},
+	},
+}
+
+// policy is policy used by checkers
+var policy = &Policy{Name: POLICY_KAOS}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SetPolicy sets policy used by checkers. If policy is nil default policy is used.
+func SetPolicy(p *Policy) {
+	if p == nil {
+		p = &Policy{Name: POLICY_KAOS}
+	}
+
+	policy = p
+}
+
+// GetPolicy returns policy with given name with merged parent policies. Custom
+// policies can extend built-in policies and override them.
+func GetPolicy(name string, custom map[string]*Policy) (*Policy, error) {
+	return resolvePolicy(name, custom, nil)
+}
+
+// GetPolicies returns names of all built-in and custom policies
+func GetPolicies(custom map[string]*Policy) []string {
+	names := slices.Collect(maps.Keys(policies))
+
+	for name := range custom {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Validate validates policy
+func (p *Policy) Validate() error {
+	for id, level := range p.Levels {
+		if _, ok := parseLevel(level); !ok {
+			return fmt.Errorf("Unknown alert level %q for check %s", level, id)
+		}
+	}
+
+	for _, name := range p.Deprecations {
+		if !slices.ContainsFunc(deprecations, func(d *deprecation) bool { return d.Name == name }) {
+			return fmt.Errorf("Unknown deprecation %q", name)
+		}
+	}
+
+	return p.validateParams()
+}
+
+// IsDisabled returns true if check with given ID is disabled by policy
+func (p *Policy) IsDisabled(id string) bool {
+	return isIgnoredCheck(p.Disabled, id)
+}
+
+// HasDeprecation returns true if optional deprecation with given name is enabled
+func (p *Policy) HasDeprecation(name string) bool {
+	return slices.Contains(p.Deprecations, name)
+}
+
+// GetLevel returns alert level for check with given ID
+func (p *Policy) GetLevel(id string) (uint8, bool) {
+	level, ok := p.Levels[id]
+
+	if !ok {
+		return 0, false
+	}
+
+	return parseLevel(level)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// resolvePolicy resolves policy with all parents
+func resolvePolicy(name string, custom map[string]*Policy, visited []string) (*Policy, error) {
+	if slices.Contains(visited, name) {
+		return nil, fmt.Errorf("Policy %q has circular dependency", name)
+	}
+
+	p, ok := custom[name]
+
+	if !ok {
+		p, ok = policies[name]
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("Unknown policy %q", name)
+	}
+
+	result := &Policy{Name: name}
+
+	if p.Extends != "" {
+		parent, err := resolvePolicy(p.Extends, custom, append(visited, name))
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = parent
+		result.Name = name
+	}
+
+	result.merge(p)
+
+	return result, result.Validate()
+}
+
+// merge merges given policy into current one
+func (p *Policy) merge(child *Policy) {
+	p.Disabled = slices.DeleteFunc(p.Disabled, func(id string) bool {
+		return isIgnoredCheck(child.Enabled, id)
+	})
+
+	p.Disabled = append(p.Disabled, child.Disabled...)
+	p.Deprecations = append(p.Deprecations, child.Deprecations...)
+
+	if p.Levels == nil {
+		p.Levels = make(map[string]string)
+	}
+
+	maps.Copy(p.Levels, child.Levels)
+
+	if p.Params == nil {
+		p.Params = make(map[string]map[string]any)
+	}
+
+	for id, params := range child.Params {
+		if p.Params[id] == nil {
+			p.Params[id] = make(map[string]any)
+		}
+
+		maps.Copy(p.Params[id], params)
+	}
+}
+
+// parseLevel parses alert level name
+func parseLevel(name string) (uint8, bool) {
+	switch name {
+	case "notice":
+		return LEVEL_NOTICE, true
+	case "warning":
+		return LEVEL_WARNING, true
+	case "error":
+		return LEVEL_ERROR, true
+	case "critical":
+		return LEVEL_CRITICAL, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,236 @@
+package check
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	// DEFAULT_PROBE_TIMEOUT is default timeout for probe requests
+	DEFAULT_PROBE_TIMEOUT = 5 * time.Second
+
+	// DEFAULT_PROBE_CONCURRENCY is default maximum number of concurrent requests
+	DEFAULT_PROBE_CONCURRENCY = 4
+
+	// DEFAULT_PROBE_CACHE_TTL is default TTL for cache records
+	DEFAULT_PROBE_CACHE_TTL = 24 * time.Hour
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// URLProber is interface for probing remote hosts
+type URLProber interface {
+	// SupportsHTTPS returns true if host with given domain supports HTTPS
+	SupportsHTTPS(domain string) bool
+}
+
+// HTTPProber is URL prober which checks hosts using TLS handshake
+type HTTPProber struct {
+	TLSConfig   *tls.Config   // TLS configuration (default configuration if nil)
+	Timeout     time.Duration // Per-request timeout
+	Concurrency int           // Maximum number of concurrent requests
+	CacheFile   string        // Path to persistent cache file
+	CacheTTL    time.Duration // Cache records TTL
+	Offline     bool          // Use only cached data
+
+	cache   map[string]probeRecord
+	sem     chan struct{}
+	mu      sync.Mutex
+	initial sync.Once
+	changed bool
+}
+
+// probeRecord contains result of host probing
+type probeRecord struct {
+	Supported bool      `json:"supported"`
+	Date      time.Time `json:"date"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// urlProber is prober used by checkers
+var urlProber URLProber = &HTTPProber{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SetURLProber sets prober used for checking URLs. If prober is nil all
+// network checks are skipped.
+func SetURLProber(p URLProber) {
+	urlProber = p
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SupportsHTTPS returns true if host with given domain supports HTTPS
+func (p *HTTPProber) SupportsHTTPS(domain string) bool {
+	p.initial.Do(p.init)
+
+	p.mu.Lock()
+	record, ok := p.cache[domain]
+	p.mu.Unlock()
+
+	if ok && (p.Offline || time.Since(record.Date) < p.CacheTTL) {
+		return record.Supported
+	}
+
+	if p.Offline {
+		return false
+	}
+
+	p.sem <- struct{}{}
+	supported, isDefinite := p.probe(domain)
+	<-p.sem
+
+	// Don't cache transport errors (timeouts, DNS errors, etc.), because
+	// they don't tell anything about HTTPS support
+	if isDefinite {
+		p.mu.Lock()
+		p.cache[domain] = probeRecord{supported, time.Now()}
+		p.changed = true
+		p.mu.Unlock()
+	}
+
+	return supported
+}
+
+// Save saves probing results to cache file. Cache file is written only if
+// there are new records.
+func (p *HTTPProber) Save() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.CacheFile == "" || !p.changed {
+		return nil
+	}
+
+	data, err := json.Marshal(p.cache)
+
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(p.CacheFile), 0750)
+
+	if err != nil {
+		return err
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(p.CacheFile), ".probe-*.json")
+
+	if err != nil {
+		return err
+	}
+
+	_, err = tmpFile.Write(data)
+
+	if err == nil {
+		err = tmpFile.Close()
+	} else {
+		tmpFile.Close()
+	}
+
+	if err == nil {
+		err = os.Rename(tmpFile.Name(), p.CacheFile)
+	}
+
+	if err != nil {
+		os.Remove(tmpFile.Name())
+		return err
+	}
+
+	p.changed = false
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// init initializes prober
+func (p *HTTPProber) init() {
+	if p.Timeout <= 0 {
+		p.Timeout = DEFAULT_PROBE_TIMEOUT
+	}
+
+	if p.Concurrency <= 0 {
+		p.Concurrency = DEFAULT_PROBE_CONCURRENCY
+	}
+
+	if p.CacheTTL <= 0 {
+		p.CacheTTL = DEFAULT_PROBE_CACHE_TTL
+	}
+
+	p.sem = make(chan struct{}, p.Concurrency)
+	p.cache = make(map[string]probeRecord)
+
+	p.loadCache()
+}
+
+// loadCache loads cache data from file
+func (p *HTTPProber) loadCache() {
+	if p.CacheFile == "" {
+		return
+	}
+
+	data, err := os.ReadFile(p.CacheFile)
+
+	if err != nil {
+		return
+	}
+
+	json.Unmarshal(data, &p.cache)
+}
+
+// probe checks if host supports HTTPS. The second value is false if result
+// is not definite (e.g. connection timeout).
+func (p *HTTPProber) probe(domain string) (bool, bool) {
+	address := domain
+
+	if _, _, err := net.SplitHostPort(domain); err != nil {
+		address = net.JoinHostPort(domain, "443")
+	}
+
+	dialer := &net.Dialer{Timeout: p.Timeout}
+	conn, err := tls.DialWithDialer(dialer, "tcp", address, p.TLSConfig)
+
+	if err == nil {
+		conn.Close()
+		return true, true
+	}
+
+	return false, isTLSRefused(err)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// isTLSRefused returns true if error means that host refused connection or
+// TLS handshake
+func isTLSRefused(err error) bool {
+	var recordErr tls.RecordHeaderError
+	var alertErr tls.AlertError
+	var certErr *tls.CertificateVerificationError
+	var hostErr x509.HostnameError
+	var authErr x509.UnknownAuthorityError
+	var invalidErr x509.CertificateInvalidError
+
+	return errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.As(err, &recordErr) || errors.As(err, &alertErr) ||
+		errors.As(err, &certErr) || errors.As(err, &hostErr) ||
+		errors.As(err, &authErr) || errors.As(err, &invalidErr)
+}
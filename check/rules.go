@@ -0,0 +1,262 @@
+package check
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/strutil"
+
+	"github.com/essentialkaos/perfecto/spec"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Rule filter modes
+const (
+	FILTER_ANY  = "any"  // Check all lines
+	FILTER_SKIP = "skip" // Skip lines matched by filter
+	FILTER_ONLY = "only" // Check only lines matched by filter
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Rule contains declarative custom rule
+type Rule struct {
+	ID          string   `toml:"id"`          // Unique rule ID (e.g. MY1)
+	Level       string   `toml:"level"`       // Alert level
+	Message     string   `toml:"message"`     // Alert message ({match} is replaced by matched text)
+	Sections    []string `toml:"sections"`    // Sections where lines are checked
+	Tags        []string `toml:"tags"`        // Header tags which are checked
+	Match       string   `toml:"match"`       // Pattern of lines
+	Exclude     []string `toml:"exclude"`     // Patterns of excluded lines
+	Comment     string   `toml:"comment"`     // Comments filter (skip by default)
+	Conditional string   `toml:"conditional"` // Filter of lines in conditional blocks (any by default)
+	Required    bool     `toml:"required"`    // Alert if no line matches pattern
+
+	level   uint8
+	match   *regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// rules contains custom rules
+var rules []*Rule
+
+// ruleIDRegExp is regexp for custom rules IDs
+var ruleIDRegExp = regexp.MustCompile(`^[A-Z]+[0-9]+$`)
+
+// reservedPrefixes contains prefixes of built-in checks IDs
+var reservedPrefixes = []string{"PF", "GO", "PY", "RS", "NJ", "LNT"}
+
+// knownSections contains names of sections which can be used in rules
+var knownSections = []string{
+	spec.SECTION_BUILD, spec.SECTION_CHANGELOG, spec.SECTION_CHECK,
+	spec.SECTION_CLEAN, spec.SECTION_DESCRIPTION, spec.SECTION_FILES,
+	spec.SECTION_GENERATE_BUILDREQUIRES, spec.SECTION_INSTALL,
+	spec.SECTION_PACKAGE, spec.SECTION_POST, spec.SECTION_POSTTRANS,
+	spec.SECTION_POSTUN, spec.SECTION_PRE, spec.SECTION_PREP,
+	spec.SECTION_PRETRANS, spec.SECTION_PREUN, spec.SECTION_SETUP,
+	spec.SECTION_TRIGGERIN, spec.SECTION_TRIGGERPOSTUN,
+	spec.SECTION_TRIGGERUN, spec.SECTION_VERIFYSCRIPT,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SetRules compiles custom rules and registers them as checkers
+func SetRules(list []*Rule) error {
+	ids := map[string]bool{}
+
+	for _, rule := range list {
+		err := rule.Compile()
+
+		if err != nil {
+			return err
+		}
+
+		if ids[rule.ID] {
+			return fmt.Errorf("Rule %s is defined more than once", rule.ID)
+		}
+
+		ids[rule.ID] = true
+	}
+
+	rules = list
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Compile validates rule and compiles its patterns
+func (r *Rule) Compile() error {
+	var ok bool
+	var err error
+
+	switch {
+	case !ruleIDRegExp.MatchString(r.ID):
+		return fmt.Errorf("Rule ID %q is invalid, it must contain upper case letters and number (e.g. MY1)", r.ID)
+	case strutil.HasPrefixAny(r.ID, reservedPrefixes...):
+		return fmt.Errorf("Rule %s uses prefix reserved for built-in checks", r.ID)
+	case r.Message == "":
+		return fmt.Errorf("Rule %s doesn't have message", r.ID)
+	case r.Match == "":
+		return fmt.Errorf("Rule %s doesn't have pattern", r.ID)
+	}
+
+	r.level, ok = parseLevel(strutil.Q(r.Level, "warning"))
+
+	if !ok {
+		return fmt.Errorf("Rule %s has unknown alert level %q", r.ID, r.Level)
+	}
+
+	for _, section := range r.Sections {
+		if !slices.Contains(knownSections, section) {
+			return fmt.Errorf("Rule %s contains unknown section %q", r.ID, section)
+		}
+	}
+
+	for _, filter := range []string{r.Comment, r.Conditional} {
+		if filter != "" && filter != FILTER_ANY && filter != FILTER_SKIP && filter != FILTER_ONLY {
+			return fmt.Errorf("Rule %s contains unknown filter mode %q", r.ID, filter)
+		}
+	}
+
+	r.match, err = regexp.Compile(r.Match)
+
+	if err != nil {
+		return fmt.Errorf("Rule %s contains invalid pattern: %w", r.ID, err)
+	}
+
+	r.exclude = nil
+
+	for _, pattern := range r.Exclude {
+		re, err := regexp.Compile(pattern)
+
+		if err != nil {
+			return fmt.Errorf("Rule %s contains invalid exclude pattern: %w", r.ID, err)
+		}
+
+		r.exclude = append(r.exclude, re)
+	}
+
+	return nil
+}
+
+// Check checks spec using rule
+func (r *Rule) Check(id string, s *spec.Spec) []Alert {
+	if len(s.Data) == 0 || r.match == nil {
+		return nil
+	}
+
+	var result []Alert
+	var isFound bool
+
+	conditional := getConditionalLines(s)
+
+	for _, line := range r.getLines(s) {
+		if !isFilterMatch(r.Comment, FILTER_SKIP, isComment(line)) ||
+			!isFilterMatch(r.Conditional, FILTER_ANY, conditional[line.Index]) {
+			continue
+		}
+
+		match := r.match.FindString(line.Text)
+
+		if match == "" && !r.match.MatchString(line.Text) {
+			continue
+		}
+
+		if slices.ContainsFunc(r.exclude, func(re *regexp.Regexp) bool { return re.MatchString(line.Text) }) {
+			continue
+		}
+
+		isFound = true
+
+		if !r.Required {
+			desc := strings.ReplaceAll(r.Message, "{match}", match)
+			result = append(result, NewAlert(id, r.level, desc, line))
+		}
+	}
+
+	if r.Required && !isFound {
+		result = append(result, NewAlert(id, r.level, r.Message, emptyLine))
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getLines returns lines checked by rule
+func (r *Rule) getLines(s *spec.Spec) []spec.Line {
+	if len(r.Sections) == 0 && len(r.Tags) == 0 {
+		return s.Data
+	}
+
+	var result []spec.Line
+
+	if len(r.Sections) != 0 {
+		for _, section := range s.GetSections(r.Sections...) {
+			result = append(result, section.Data...)
+		}
+	}
+
+	if len(r.Tags) == 0 {
+		return result
+	}
+
+	for _, header := range s.GetHeaders() {
+		for _, line := range header.Data {
+			tag, _, ok := strings.Cut(strings.TrimSpace(line.Text), ":")
+
+			if ok && slices.ContainsFunc(r.Tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+				result = append(result, line)
+			}
+		}
+	}
+
+	return result
+}
+
+// getConditionalLines returns indexes of lines inside conditional blocks
+func getConditionalLines(s *spec.Spec) map[int]bool {
+	var depth int
+
+	result := make(map[int]bool)
+
+	for _, line := range s.Data {
+		directive := strutil.ReadField(strings.TrimSpace(line.Text), 0, true, ' ', '\t')
+
+		switch directive {
+		case "%if", "%ifarch", "%ifnarch", "%ifos", "%ifnos":
+			depth++
+		case "%endif":
+			depth = max(depth-1, 0)
+		default:
+			result[line.Index] = depth > 0
+		}
+	}
+
+	return result
+}
+
+// isFilterMatch returns true if line with given property passes filter
+func isFilterMatch(filter, defFilter string, value bool) bool {
+	switch strutil.Q(filter, defFilter) {
+	case FILTER_SKIP:
+		return !value
+	case FILTER_ONLY:
+		return value
+	}
+
+	return true
+}
@@ -0,0 +1,189 @@
+package check
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/strutil"
+
+	"mvdan.cc/sh/v3/syntax"
+
+	"github.com/essentialkaos/perfecto/spec"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// shellError contains info about shell syntax error
+type shellError struct {
+	Line   spec.Line
+	Column int
+	Text   string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parseShellScript masks RPM macros in section data and parses it as bash script.
+// It returns nil if script doesn't contain syntax errors.
+func parseShellScript(data []spec.Line) *shellError {
+	script := strings.Join(maskSectionData(data), "\n")
+	parser := syntax.NewParser(syntax.Variant(syntax.LangBash))
+
+	_, err := parser.Parse(strings.NewReader(script), "")
+
+	if err == nil {
+		return nil
+	}
+
+	var parseErr syntax.ParseError
+	var langErr syntax.LangError
+
+	switch {
+	case errors.As(err, &parseErr):
+		return newShellError(data, parseErr.Pos, parseErr.Text)
+	case errors.As(err, &langErr):
+		return newShellError(data, langErr.Pos, langErr.Feature+" is not supported")
+	}
+
+	return &shellError{Line: emptyLine, Text: err.Error()}
+}
+
+// maskSectionData removes RPM conditionals and replaces RPM macros by
+// placeholders. For conditional blocks only the first
+// branch is kept.
+func maskSectionData(data []spec.Line) []string {
+	var result []string
+	var skip []bool
+
+	for _, line := range data {
+		text := strings.TrimLeft(line.Text, "\t ")
+		directive := strutil.ReadField(text, 0, true, ' ', '\t')
+
+		switch directive {
+		case "%if", "%ifarch", "%ifnarch", "%ifos", "%ifnos":
+			skip = append(skip, len(skip) != 0 && skip[len(skip)-1])
+			result = append(result, "")
+			continue
+		case "%else", "%elif", "%elifarch", "%elifos":
+			if len(skip) != 0 {
+				skip[len(skip)-1] = true
+			}
+			result = append(result, "")
+			continue
+		case "%endif":
+			if len(skip) != 0 {
+				skip = skip[:len(skip)-1]
+			}
+			result = append(result, "")
+			continue
+		case "%define", "%global", "%undefine":
+			result = append(result, "")
+			continue
+		}
+
+		if len(skip) != 0 && skip[len(skip)-1] {
+			result = append(result, "")
+			continue
+		}
+
+		result = append(result, maskMacros(line.Text))
+	}
+
+	return result
+}
+
+// maskMacros replaces RPM macros in given text by placeholders with the same
+// length. Escaped percent sign (%%) is replaced by single % as RPM does.
+func maskMacros(text string) string {
+	var buf strings.Builder
+
+	for i := 0; i < len(text); i++ {
+		if text[i] != '%' || i+1 >= len(text) {
+			buf.WriteByte(text[i])
+			continue
+		}
+
+		next := text[i+1]
+
+		switch {
+		case next == '%':
+			buf.WriteByte('%')
+			i++
+		case next == '{' || next == '(' || next == '[':
+			end := findClosingBracket(text, i+1)
+			buf.WriteString(strings.Repeat("_", end-i+1))
+			i = end
+		case isMacroNameChar(next):
+			end := i + 1
+
+			for end < len(text) && isMacroNameChar(text[end]) {
+				end++
+			}
+
+			buf.WriteString(strings.Repeat("_", end-i))
+			i = end - 1
+		default:
+			buf.WriteByte(text[i])
+		}
+	}
+
+	return buf.String()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// newShellError creates new shell error and maps error position to spec line
+func newShellError(data []spec.Line, pos syntax.Pos, text string) *shellError {
+	index := int(pos.Line()) - 1
+
+	if index < 0 || index >= len(data) {
+		return &shellError{Line: emptyLine, Text: text}
+	}
+
+	return &shellError{Line: data[index], Column: int(pos.Col()), Text: text}
+}
+
+// findClosingBracket returns index of closing bracket for bracket with given index
+func findClosingBracket(text string, index int) int {
+	var closing byte
+
+	switch text[index] {
+	case '{':
+		closing = '}'
+	case '(':
+		closing = ')'
+	default:
+		closing = ']'
+	}
+
+	depth := 0
+
+	for i := index; i < len(text); i++ {
+		switch text[i] {
+		case text[index]:
+			depth++
+		case closing:
+			depth--
+
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return len(text) - 1
+}
+
+// isMacroNameChar returns true if given char can be used in macro name
+func isMacroNameChar(c byte) bool {
+	return c == '_' || c == '?' || c == '!' ||
+		(c >= 'a' && c <= 'z') ||
+		(c >= 'A' && c <= 'Z') ||
+		(c >= '0' && c <= '9')
+}
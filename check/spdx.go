@@ -0,0 +1,433 @@
+package check
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	_ "embed"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+//go:embed data/spdx-licenses.txt
+var spdxLicensesData string
+
+//go:embed data/spdx-exceptions.txt
+var spdxExceptionsData string
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// spdxLicenses is set with all known SPDX license identifiers
+var spdxLicenses = parseSPDXList(spdxLicensesData)
+
+// spdxExceptions is set with all known SPDX license exceptions
+var spdxExceptions = parseSPDXList(spdxExceptionsData)
+
+// spdxDeprecated contains deprecated SPDX identifiers and their replacements
+var spdxDeprecated = map[string]string{
+	"AGPL-1.0":      "AGPL-1.0-only",
+	"AGPL-3.0":      "AGPL-3.0-only",
+	"GFDL-1.1":      "GFDL-1.1-only",
+	"GFDL-1.2":      "GFDL-1.2-only",
+	"GFDL-1.3":      "GFDL-1.3-only",
+	"GPL-1.0":       "GPL-1.0-only",
+	"GPL-1.0+":      "GPL-1.0-or-later",
+	"GPL-2.0":       "GPL-2.0-only",
+	"GPL-2.0+":      "GPL-2.0-or-later",
+	"GPL-3.0":       "GPL-3.0-only",
+	"GPL-3.0+":      "GPL-3.0-or-later",
+	"LGPL-2.0":      "LGPL-2.0-only",
+	"LGPL-2.0+":     "LGPL-2.0-or-later",
+	"LGPL-2.1":      "LGPL-2.1-only",
+	"LGPL-2.1+":     "LGPL-2.1-or-later",
+	"LGPL-3.0":      "LGPL-3.0-only",
+	"LGPL-3.0+":     "LGPL-3.0-or-later",
+	"StandardML-NJ": "SMLNJ",
+	"wxWindows":     "GPL-2.0-or-later WITH WxWindows-exception-3.1",
+}
+
+// spdxLegacyNames contains legacy Fedora short license names and their SPDX
+// equivalents. Names with more than one possible equivalent can't be converted
+// automatically.
+var spdxLegacyNames = map[string][]string{
+	"AGPLv1":                 {"AGPL-1.0-only"},
+	"AGPLv3":                 {"AGPL-3.0-only"},
+	"AGPLv3+":                {"AGPL-3.0-or-later"},
+	"ASL 1.0":                {"Apache-1.0"},
+	"ASL 1.1":                {"Apache-1.1"},
+	"ASL 2.0":                {"Apache-2.0"},
+	"Artistic 2.0":           {"Artistic-2.0"},
+	"Artistic clarified":     {"ClArtistic"},
+	"BSD":                    {"BSD-3-Clause", "BSD-2-Clause", "BSD-Source-Code"},
+	"BSD with advertising":   {"BSD-4-Clause", "BSD-4-Clause-UC"},
+	"Bitstream Vera":         {"Bitstream-Vera"},
+	"Boost":                  {"BSL-1.0"},
+	"CC0":                    {"CC0-1.0"},
+	"CC-BY":                  {"CC-BY-3.0", "CC-BY-4.0"},
+	"CC-BY-SA":               {"CC-BY-SA-3.0", "CC-BY-SA-4.0"},
+	"CDDL":                   {"CDDL-1.0"},
+	"CPL":                    {"CPL-1.0"},
+	"EPL":                    {"EPL-1.0"},
+	"EPL-2.0":                {"EPL-2.0"},
+	"GFDL":                   {"GFDL-1.1-or-later", "GFDL-1.2-or-later", "GFDL-1.3-or-later"},
+	"GPL+":                   {"GPL-1.0-or-later"},
+	"GPLv1":                  {"GPL-1.0-only"},
+	"GPLv2":                  {"GPL-2.0-only"},
+	"GPLv2+":                 {"GPL-2.0-or-later"},
+	"GPLv2 with exceptions":  {"GPL-2.0-only WITH Classpath-exception-2.0", "GPL-2.0-only WITH GCC-exception-2.0"},
+	"GPLv2+ with exceptions": {"GPL-2.0-or-later WITH Classpath-exception-2.0", "GPL-2.0-or-later WITH GCC-exception-2.0"},
+	"GPLv3":                  {"GPL-3.0-only"},
+	"GPLv3+":                 {"GPL-3.0-or-later"},
+	"GPLv3 with exceptions":  {"GPL-3.0-only WITH GCC-exception-3.1", "GPL-3.0-only WITH Bison-exception-2.2"},
+	"GPLv3+ with exceptions": {"GPL-3.0-or-later WITH GCC-exception-3.1", "GPL-3.0-or-later WITH Bison-exception-2.2"},
+	"IBM":                    {"IPL-1.0"},
+	"JasPer":                 {"JasPer-2.0"},
+	"LGPLv2":                 {"LGPL-2.0-only", "LGPL-2.1-only"},
+	"LGPLv2+":                {"LGPL-2.0-or-later", "LGPL-2.1-or-later"},
+	"LGPLv2.1":               {"LGPL-2.1-only"},
+	"LGPLv2.1+":              {"LGPL-2.1-or-later"},
+	"LGPLv3":                 {"LGPL-3.0-only"},
+	"LGPLv3+":                {"LGPL-3.0-or-later"},
+	"LPPL":                   {"LPPL-1.3a", "LPPL-1.3c"},
+	"MIT with advertising":   {"MIT-advertising", "MIT-enna", "MIT-feh"},
+	"MPLv1.0":                {"MPL-1.0"},
+	"MPLv1.1":                {"MPL-1.1"},
+	"MPLv2.0":                {"MPL-2.0"},
+	"Netscape":               {"NPL-1.1"},
+	"OFL":                    {"OFL-1.1"},
+	"OpenLDAP":               {"OLDAP-2.8"},
+	"PHP":                    {"PHP-3.01", "PHP-3.0"},
+	"Public Domain":          {"LicenseRef-Fedora-Public-Domain"},
+	"Python":                 {"Python-2.0", "PSF-2.0"},
+	"QPL":                    {"QPL-1.0"},
+	"SPL":                    {"SPL-1.0"},
+	"UCD":                    {"Unicode-DFS-2016"},
+	"ZPLv1.1":                {"ZPL-1.1"},
+	"ZPLv2.0":                {"ZPL-2.0"},
+	"ZPLv2.1":                {"ZPL-2.1"},
+	"Zend":                   {"Zend-2.0"},
+	"zlib":                   {"Zlib"},
+}
+
+// spdxLegacySplitRegExp is regexp for splitting legacy license expressions
+var spdxLegacySplitRegExp = regexp.MustCompile(`(\(|\)|\s+(?:and|or|AND|OR)\s+)`)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// spdxParser is SPDX license expression parser
+type spdxParser struct {
+	tokens []string
+	pos    int
+	ids    []string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parseSPDXExpression parses SPDX license expression and returns slice with
+// all license identifiers used in it
+func parseSPDXExpression(expr string) ([]string, error) {
+	p := &spdxParser{tokens: tokenizeSPDXExpression(expr)}
+
+	if len(p.tokens) == 0 {
+		return nil, fmt.Errorf("expression is empty")
+	}
+
+	err := p.parseOr()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.tokens) {
+		return nil, unexpectedSPDXToken(p.tokens[p.pos])
+	}
+
+	return p.ids, nil
+}
+
+// convertLegacyLicense converts license expression with legacy Fedora names to
+// SPDX expression. It returns converted expression and slice with legacy names
+// which can't be converted automatically.
+func convertLegacyLicense(expr string) (string, []string, bool) {
+	var result strings.Builder
+	var ambiguous []string
+	var hasLegacy bool
+
+	last := 0
+	locs := spdxLegacySplitRegExp.FindAllStringIndex(expr, -1)
+	locs = append(locs, []int{len(expr), len(expr)})
+
+	for _, loc := range locs {
+		term := strings.TrimSpace(expr[last:loc[0]])
+		ids, legacy, ok := convertLegacyTerm(term)
+
+		if !ok {
+			return "", nil, false
+		}
+
+		if legacy {
+			hasLegacy = true
+		}
+
+		if len(ids) > 1 {
+			ambiguous = append(ambiguous, term)
+		} else if len(ids) == 1 {
+			result.WriteString(ids[0])
+		}
+
+		op := expr[loc[0]:loc[1]]
+
+		if strings.TrimSpace(op) == "and" || strings.TrimSpace(op) == "or" {
+			hasLegacy = true
+		}
+
+		result.WriteString(normalizeLegacyOperator(op))
+
+		last = loc[1]
+	}
+
+	switch {
+	case !hasLegacy:
+		return "", nil, false
+	case len(ambiguous) != 0:
+		return "", ambiguous, true
+	}
+
+	converted := result.String()
+
+	if _, err := parseSPDXExpression(converted); err != nil {
+		return "", nil, false
+	}
+
+	return converted, nil, true
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parseOr parses OR expression
+func (p *spdxParser) parseOr() error {
+	err := p.parseAnd()
+
+	if err != nil {
+		return err
+	}
+
+	for p.peek() == "OR" {
+		p.pos++
+
+		err = p.parseAnd()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseAnd parses AND expression
+func (p *spdxParser) parseAnd() error {
+	err := p.parseWith()
+
+	if err != nil {
+		return err
+	}
+
+	for p.peek() == "AND" {
+		p.pos++
+
+		err = p.parseWith()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseWith parses license with optional exception
+func (p *spdxParser) parseWith() error {
+	err := p.parseTerm()
+
+	if err != nil {
+		return err
+	}
+
+	if p.peek() != "WITH" {
+		return nil
+	}
+
+	p.pos++
+
+	exception := p.next()
+
+	switch {
+	case exception == "":
+		return fmt.Errorf("exception expected after WITH")
+	case !isSPDXException(exception):
+		return fmt.Errorf("unknown license exception %q", exception)
+	}
+
+	return nil
+}
+
+// parseTerm parses license identifier or expression in parentheses
+func (p *spdxParser) parseTerm() error {
+	token := p.next()
+
+	switch token {
+	case "":
+		return fmt.Errorf("license identifier expected")
+	case "(":
+		err := p.parseOr()
+
+		if err != nil {
+			return err
+		}
+
+		if p.next() != ")" {
+			return fmt.Errorf("missing closing parenthesis")
+		}
+
+		return nil
+	case ")":
+		return unexpectedSPDXToken(token)
+	}
+
+	if isSPDXOperator(token) {
+		return unexpectedSPDXToken(token)
+	}
+
+	p.ids = append(p.ids, token)
+
+	return nil
+}
+
+// peek returns current token without moving position
+func (p *spdxParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+
+	return p.tokens[p.pos]
+}
+
+// next returns current token and moves position
+func (p *spdxParser) next() string {
+	token := p.peek()
+
+	if token != "" {
+		p.pos++
+	}
+
+	return token
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// tokenizeSPDXExpression splits SPDX expression to tokens
+func tokenizeSPDXExpression(expr string) []string {
+	expr = strings.ReplaceAll(expr, "(", " ( ")
+	expr = strings.ReplaceAll(expr, ")", " ) ")
+
+	return strings.Fields(expr)
+}
+
+// convertLegacyTerm converts single license term to SPDX identifiers
+func convertLegacyTerm(term string) ([]string, bool, bool) {
+	if term == "" {
+		return nil, false, true
+	}
+
+	if ids, ok := spdxLegacyNames[term]; ok {
+		return ids, true, true
+	}
+
+	if replacement, ok := spdxDeprecated[term]; ok {
+		return []string{replacement}, true, true
+	}
+
+	ids, err := parseSPDXExpression(term)
+
+	if err != nil {
+		return nil, false, false
+	}
+
+	for _, id := range ids {
+		if !isSPDXLicense(id) {
+			return nil, false, false
+		}
+	}
+
+	return []string{term}, false, true
+}
+
+// normalizeLegacyOperator converts legacy operator to SPDX operator
+func normalizeLegacyOperator(op string) string {
+	switch strings.TrimSpace(op) {
+	case "", "(", ")":
+		return strings.TrimSpace(op)
+	}
+
+	return " " + strings.ToUpper(strings.TrimSpace(op)) + " "
+}
+
+// isSPDXLicense returns true if given identifier is known SPDX license
+func isSPDXLicense(id string) bool {
+	id = strings.TrimSuffix(id, "+")
+
+	if strings.HasPrefix(id, "LicenseRef-") || strings.HasPrefix(id, "DocumentRef-") {
+		return true
+	}
+
+	return spdxLicenses[strings.ToLower(id)]
+}
+
+// isSPDXException returns true if given identifier is known SPDX exception
+func isSPDXException(id string) bool {
+	return spdxExceptions[strings.ToLower(id)]
+}
+
+// isSPDXOperator returns true if given token is SPDX operator in any case
+func isSPDXOperator(token string) bool {
+	switch strings.ToUpper(token) {
+	case "AND", "OR", "WITH":
+		return true
+	}
+
+	return false
+}
+
+// unexpectedSPDXToken returns error for unexpected token
+func unexpectedSPDXToken(token string) error {
+	if isSPDXOperator(token) && strings.ToUpper(token) != token {
+		return fmt.Errorf("operator %q must be in upper case", token)
+	}
+
+	return fmt.Errorf("unexpected %q", token)
+}
+
+// parseSPDXList parses embedded list with SPDX identifiers
+func parseSPDXList(data string) map[string]bool {
+	result := make(map[string]bool)
+
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		result[strings.ToLower(line)] = true
+	}
+
+	return result
+}
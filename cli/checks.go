@@ -0,0 +1,85 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/mathutil"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/terminal"
+
+	"github.com/essentialkaos/perfecto/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// listChecks prints info about all available checks
+func listChecks() int {
+	format := options.GetS(OPT_FORMAT)
+
+	if format != "" && format != FORMAT_JSON {
+		terminal.Error("Output format %q is not supported for checks list", format)
+		return 1
+	}
+
+	err := configurePolicy()
+
+	if err != nil {
+		terminal.Error(err)
+		return 1
+	}
+
+	checks, errs := check.GetChecks()
+
+	for _, err := range errs {
+		terminal.Warn(err)
+	}
+
+	if format == FORMAT_JSON {
+		data, _ := json.MarshalIndent(checks, "", "  ")
+		fmt.Println(string(data))
+	} else {
+		printChecks(checks)
+	}
+
+	if len(errs) != 0 {
+		return 1
+	}
+
+	return 0
+}
+
+// printChecks prints checks grouped by their source
+func printChecks(checks []check.CheckInfo) {
+	var group string
+	var size int
+
+	for _, info := range checks {
+		size = mathutil.Max(size, len(info.ID))
+	}
+
+	for _, info := range checks {
+		if info.Group != group {
+			if group != "" {
+				fmtc.NewLine()
+			}
+
+			group = info.Group
+			fmtc.Printfn("{*}%s{!}\n", group)
+		}
+
+		if info.IsDisabled {
+			fmtc.Printfn("  {s}%-*s  %s (disabled){!}", size, info.ID, info.Desc)
+		} else {
+			fmtc.Printfn("  {y}%-*s{!}  %s", size, info.ID, info.Desc)
+		}
+	}
+}
@@ -11,10 +11,12 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"slices"
 	"strings"
 
 	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fsutil"
 	"github.com/essentialkaos/ek/v13/mathutil"
 	"github.com/essentialkaos/ek/v13/options"
 	"github.com/essentialkaos/ek/v13/strutil"
@@ -45,14 +47,28 @@ const (
 	DESC = "Tool for checking perfectly written RPM specs"
 )
 
+// Commands
+const (
+	CMD_OUTDATED = "outdated"
+)
+
 // Options
 const (
 	OPT_FORMAT      = "f:format"
+	OPT_CONFIG      = "C:config"
+	OPT_POLICY      = "p:policy"
 	OPT_LINT_CONFIG = "c:lint-config"
 	OPT_ERROR_LEVEL = "e:error-level"
 	OPT_IGNORE      = "I:ignore"
+	OPT_WITH        = "w:with"
+	OPT_WITHOUT     = "W:without"
 	OPT_QUIET       = "q:quiet"
+	OPT_OFFLINE     = "O:offline"
+	OPT_FEED        = "F:feed"
 	OPT_PAGER       = "P:pager"
+	OPT_LIST_CHECKS = "L:list-checks"
+	OPT_CONTEXT     = "x:context"
+	OPT_GROUP       = "G:group-by-section"
 	OPT_NO_LINT     = "nl:no-lint"
 	OPT_NO_COLOR    = "nc:no-color"
 	OPT_HELP        = "h:help"
@@ -65,15 +81,25 @@ const (
 
 // Supported formats
 const (
-	FORMAT_FULL    = "full"
-	FORMAT_SUMMARY = "summary"
-	FORMAT_SHORT   = "short"
-	FORMAT_TINY    = "tiny"
-	FORMAT_GITHUB  = "github"
-	FORMAT_JSON    = "json"
-	FORMAT_XML     = "xml"
+	FORMAT_FULL       = "full"
+	FORMAT_SUMMARY    = "summary"
+	FORMAT_SHORT      = "short"
+	FORMAT_TINY       = "tiny"
+	FORMAT_GITHUB     = "github"
+	FORMAT_JSON       = "json"
+	FORMAT_XML        = "xml"
+	FORMAT_CHECKSTYLE = "checkstyle"
+	FORMAT_JUNIT      = "junit"
+	FORMAT_GITLAB     = "gitlab"
+	FORMAT_RDJSON     = "rdjson"
+	FORMAT_RDJSONL    = "rdjsonl"
+	FORMAT_MARKDOWN   = "markdown"
+	FORMAT_HTML       = "html"
 )
 
+// CONFIG_FILE is name of configuration file used by default
+const CONFIG_FILE = ".perfecto.toml"
+
 // Levels
 const (
 	LEVEL_NOTICE   = "notice"
@@ -87,10 +113,19 @@ const (
 // optMap is map with all supported options
 var optMap = options.Map{
 	OPT_IGNORE:      {Mergeble: true, Alias: "A:absolve"},
+	OPT_WITH:        {Mergeble: true},
+	OPT_WITHOUT:     {Mergeble: true},
 	OPT_FORMAT:      {},
+	OPT_CONFIG:      {},
+	OPT_POLICY:      {},
 	OPT_LINT_CONFIG: {},
 	OPT_ERROR_LEVEL: {},
+	OPT_FEED:        {},
 	OPT_QUIET:       {Type: options.BOOL},
+	OPT_OFFLINE:     {Type: options.BOOL},
+	OPT_LIST_CHECKS: {Type: options.BOOL},
+	OPT_CONTEXT:     {Type: options.INT, Value: 2, Min: 0, Max: 10},
+	OPT_GROUP:       {Type: options.BOOL},
 	OPT_NO_LINT:     {Type: options.BOOL},
 	OPT_NO_COLOR:    {Type: options.BOOL},
 	OPT_HELP:        {Type: options.BOOL},
@@ -110,6 +145,13 @@ var formats = []string{
 	FORMAT_GITHUB,
 	FORMAT_JSON,
 	FORMAT_XML,
+	FORMAT_CHECKSTYLE,
+	FORMAT_JUNIT,
+	FORMAT_GITLAB,
+	FORMAT_RDJSON,
+	FORMAT_RDJSONL,
+	FORMAT_MARKDOWN,
+	FORMAT_HTML,
 	"",
 }
 
@@ -152,12 +194,21 @@ func Run(gitRev string, gomod []byte) {
 			WithApps(getRPMLintInfo()).
 			Print()
 		os.Exit(0)
+	case options.GetB(OPT_LIST_CHECKS):
+		os.Exit(listChecks())
 	case options.GetB(OPT_HELP) || len(args) == 0:
 		genUsage().Print()
 		os.Exit(0)
 	}
 
-	ec, err := process(args)
+	var ec int
+	var err error
+
+	if args.Get(0).Is(CMD_OUTDATED) {
+		ec, err = processOutdated(args[1:])
+	} else {
+		ec, err = process(args)
+	}
 
 	if err != nil {
 		terminal.Error(err)
@@ -209,16 +260,97 @@ func process(files options.Arguments) (int, error) {
 		return 1, fmt.Errorf("Output format %q is not supported", format)
 	}
 
+	err = configurePolicy()
+
+	if err != nil {
+		return 1, err
+	}
+
 	rndr := getRenderer(format, files)
 
+	prober := configureProber()
+
 	for _, file := range files {
 		ec := checkSpec(file.Clean().String(), rndr)
 		exitCode = mathutil.Max(ec, exitCode)
 	}
 
+	err = prober.Save()
+
+	if err != nil {
+		terminal.Warn("Can't save probing results to cache: %v", err)
+	}
+
+	if flusher, ok := rndr.(render.Flusher); ok {
+		err = flusher.Flush()
+
+		if err != nil {
+			return 1, err
+		}
+	}
+
 	return exitCode, nil
 }
 
+// configureProber configures prober used for checking remote hosts
+func configureProber() *check.HTTPProber {
+	prober := &check.HTTPProber{Offline: options.GetB(OPT_OFFLINE)}
+	cacheDir, err := os.UserCacheDir()
+
+	if err == nil {
+		prober.CacheFile = filepath.Join(cacheDir, APP, "probe.json")
+	}
+
+	check.SetURLProber(prober)
+
+	return prober
+}
+
+// configurePolicy reads configuration file and configures checks policy,
+// custom rules and plugins
+func configurePolicy() error {
+	config := &check.Config{}
+	configFile := options.GetS(OPT_CONFIG)
+
+	if configFile == "" && fsutil.IsExist(CONFIG_FILE) {
+		configFile = CONFIG_FILE
+	}
+
+	if configFile != "" {
+		var err error
+
+		config, err = check.ReadConfig(configFile)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	policy, err := config.GetPolicy(strutil.Q(options.GetS(OPT_POLICY), config.Policy))
+
+	if err != nil {
+		return err
+	}
+
+	check.SetPolicy(policy)
+
+	err = check.SetRules(config.Rules)
+
+	if err != nil {
+		return err
+	}
+
+	plugins, err := config.GetPlugins()
+
+	if err != nil {
+		return err
+	}
+
+	check.SetPlugins(plugins)
+
+	return nil
+}
+
 // checkSpec check spec file
 func checkSpec(file string, rndr render.Renderer) int {
 	var ignoreChecks []string
@@ -234,6 +366,13 @@ func checkSpec(file string, rndr render.Renderer) int {
 		ignoreChecks = strings.Split(options.GetS(OPT_IGNORE), ",")
 	}
 
+	if options.Has(OPT_WITH) || options.Has(OPT_WITHOUT) {
+		s = s.Variant(
+			strutil.Fields(options.GetS(OPT_WITH)),
+			strutil.Fields(options.GetS(OPT_WITHOUT)),
+		)
+	}
+
 	report := check.Check(
 		s, !options.GetB(OPT_NO_LINT),
 		options.GetS(OPT_LINT_CONFIG),
@@ -264,7 +403,7 @@ func getFormat(files options.Arguments) (string, error) {
 
 	if len(files) > 1 {
 		switch format {
-		case FORMAT_JSON, FORMAT_XML:
+		case FORMAT_JSON:
 			return "", fmt.Errorf("Can't check multiple files with %q output format", format)
 		case "":
 			format = FORMAT_TINY
@@ -286,7 +425,21 @@ func getRenderer(format string, files options.Arguments) render.Renderer {
 	case FORMAT_JSON:
 		return &render.JSONRenderer{}
 	case FORMAT_XML:
-		return &render.XMLRenderer{}
+		return &render.XMLRenderer{Version: VER}
+	case FORMAT_CHECKSTYLE:
+		return &render.CheckstyleRenderer{}
+	case FORMAT_JUNIT:
+		return &render.JUnitRenderer{}
+	case FORMAT_GITLAB:
+		return &render.GitlabRenderer{}
+	case FORMAT_RDJSON:
+		return &render.RDJSONRenderer{}
+	case FORMAT_RDJSONL:
+		return &render.RDJSONRenderer{Lines: true}
+	case FORMAT_MARKDOWN:
+		return &render.MarkdownRenderer{}
+	case FORMAT_HTML:
+		return &render.HTMLRenderer{Version: VER}
 	case FORMAT_SUMMARY:
 		return &render.TerminalRenderer{
 			Format:       FORMAT_SUMMARY,
@@ -307,9 +460,11 @@ func getRenderer(format string, files options.Arguments) render.Renderer {
 		}
 	default:
 		return &render.TerminalRenderer{
-			Format:       FORMAT_FULL,
-			FilenameSize: maxFilenameSize,
-			UsePager:     options.GetB(OPT_PAGER),
+			Format:         FORMAT_FULL,
+			FilenameSize:   maxFilenameSize,
+			Context:        options.GetI(OPT_CONTEXT),
+			UsePager:       options.GetB(OPT_PAGER),
+			GroupBySection: options.GetB(OPT_GROUP),
 		}
 	}
 }
@@ -411,12 +566,23 @@ func genUsage() *usage.Info {
 
 	info.AppNameColorTag = colorTagApp
 
-	info.AddOption(OPT_IGNORE, "Disable one or more checks by their ID", "id…")
-	info.AddOption(OPT_FORMAT, "Output format {s-}(summary|tiny|short|github|json|xml){!}", "format")
+	info.AddCommand(CMD_OUTDATED, "Check upstream projects for new releases", "spec…")
+
+	info.AddOption(OPT_IGNORE, "Disable one or more checks by their ID, group {s-}(PF, GO, PY, RS, NJ){!} or plugin name", "id…")
+	info.AddOption(OPT_WITH, "Enable build condition", "cond…")
+	info.AddOption(OPT_WITHOUT, "Disable build condition", "cond…")
+	info.AddOption(OPT_CONFIG, "Path to configuration file {s-}(default: "+CONFIG_FILE+"){!}", "file")
+	info.AddOption(OPT_POLICY, "Checks policy {s-}(kaos|fedora|epel|opensuse){!}", "name")
+	info.AddOption(OPT_FORMAT, "Output format {s-}(summary|tiny|short|github|json|xml|checkstyle|junit|gitlab|rdjson|rdjsonl|markdown|html){!}", "format")
+	info.AddOption(OPT_CONTEXT, "Number of spec lines around alert line in full report {s-}(0-10, default: 2){!}", "num")
+	info.AddOption(OPT_GROUP, "Group alerts by spec sections in full report")
 	info.AddOption(OPT_LINT_CONFIG, "Path to RPMLint configuration file", "file")
 	info.AddOption(OPT_ERROR_LEVEL, "Return non-zero exit code if alert level greater than given {s-}(notice|warning|error|critical){!}", "level")
 	info.AddOption(OPT_QUIET, "Suppress all normal output")
+	info.AddOption(OPT_OFFLINE, "Don't send network requests, use only cached data")
+	info.AddOption(OPT_FEED, "URL of releases feed for {y}outdated{!} command {s-}({type} and {project} are replaced){!}", "url")
 	info.AddOption(OPT_PAGER, "Use pager for long output")
+	info.AddOption(OPT_LIST_CHECKS, "List all available checks")
 	info.AddOption(OPT_NO_LINT, "Disable RPMLint checks")
 	info.AddOption(OPT_NO_COLOR, "Disable colors in output")
 	info.AddOption(OPT_HELP, "Show this help message")
@@ -434,6 +600,36 @@ func genUsage() *usage.Info {
 		"Check spec without PF2 and PF12 checks",
 	)
 
+	info.AddExample(
+		"--ignore PY,RS1 app.spec",
+		"Check spec without Python ecosystem checks and RS1 check",
+	)
+
+	info.AddExample(
+		"--ignore signing app.spec",
+		"Check spec without checks from perfecto-plugin-signing plugin",
+	)
+
+	info.AddExample(
+		"--list-checks --policy fedora",
+		"List all available checks including checks from plugins and custom rules",
+	)
+
+	info.AddExample(
+		"--with static --without tests app.spec",
+		"Check spec build variant with enabled static and disabled tests build conditions",
+	)
+
+	info.AddExample(
+		"--policy fedora app.spec",
+		"Check spec using Fedora packaging policy",
+	)
+
+	info.AddExample(
+		"--group-by-section --context 5 app.spec",
+		"Check spec and print full report with alerts grouped by sections and 5 lines of context",
+	)
+
 	info.AddExample(
 		"--format tiny app.spec",
 		"Check spec and print tiny report",
@@ -449,6 +645,31 @@ func genUsage() *usage.Info {
 		"Check spec, generate report in JSON format and save as report.json",
 	)
 
+	info.AddExample(
+		"--format junit *.spec 1> perfecto.xml",
+		"Check all specs and save report in JUnit format as perfecto.xml",
+	)
+
+	info.AddExample(
+		"--format gitlab *.spec 1> gl-code-quality-report.json",
+		"Check all specs and save report in GitLab Code Quality format",
+	)
+
+	info.AddExample(
+		"--format html *.spec 1> report.html",
+		"Check all specs and save report as HTML page",
+	)
+
+	info.AddExample(
+		"outdated app.spec",
+		"Check if new release of upstream project is available",
+	)
+
+	info.AddExample(
+		"outdated --feed https://mirror.domain.com/{type}/{project} --format json *.spec",
+		"Check specs for outdated versions using local releases feed and print result in JSON format",
+	)
+
 	return info
 }
 
@@ -0,0 +1,107 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fmtutil/table"
+	"github.com/essentialkaos/ek/v13/options"
+	"github.com/essentialkaos/ek/v13/strutil"
+
+	"github.com/essentialkaos/perfecto/spec"
+	"github.com/essentialkaos/perfecto/upstream"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// processOutdated checks specs for new releases of upstream projects
+func processOutdated(files options.Arguments) (int, error) {
+	var exitCode int
+	var results []*upstream.Result
+
+	format := options.GetS(OPT_FORMAT)
+
+	switch {
+	case len(files) == 0:
+		return 1, fmt.Errorf("You must define at least one spec file")
+	case format != "" && format != FORMAT_JSON:
+		return 1, fmt.Errorf("Output format %q is not supported by %q command", format, CMD_OUTDATED)
+	case options.GetB(OPT_OFFLINE):
+		return 1, fmt.Errorf("Can't check upstream versions in offline mode")
+	}
+
+	feed := &upstream.Feed{URL: options.GetS(OPT_FEED)}
+
+	for _, file := range files {
+		file := file.Clean().String()
+		s, err := spec.Read(file)
+
+		if err != nil {
+			results = append(results, &upstream.Result{File: file, Error: err.Error()})
+		} else {
+			results = append(results, feed.Check(s))
+		}
+
+		result := results[len(results)-1]
+
+		if result.IsOutdated || result.Error != "" {
+			exitCode = 1
+		}
+	}
+
+	if options.GetB(OPT_QUIET) {
+		return exitCode, nil
+	}
+
+	if format == FORMAT_JSON {
+		data, _ := json.MarshalIndent(results, "", "  ")
+		fmt.Println(string(data))
+	} else {
+		printOutdatedTable(results)
+	}
+
+	return exitCode, nil
+}
+
+// printOutdatedTable prints results of upstream versions check as a table
+func printOutdatedTable(results []*upstream.Result) {
+	t := table.NewTable("SPEC", "PROJECT", "CURRENT", "LATEST", "STATUS")
+	t.FullScreen = false
+
+	for _, result := range results {
+		var project, status string
+
+		if result.Project != nil {
+			project = result.Project.Type + ":" + result.Project.Name
+		}
+
+		switch {
+		case result.Error != "":
+			status = "{r}" + result.Error + "{!}"
+		case result.IsOutdated:
+			status = "{y}outdated{!}"
+		default:
+			status = "{g}up-to-date{!}"
+		}
+
+		t.Add(
+			strutil.Exclude(result.File, ".spec"),
+			strutil.Q(project, "{s-}—{!}"),
+			strutil.Q(result.Current, "{s-}—{!}"),
+			strutil.Q(result.Latest, "{s-}—{!}"),
+			status,
+		)
+	}
+
+	t.Render()
+
+	fmtc.NewLine()
+}
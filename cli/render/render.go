@@ -8,11 +8,19 @@ package render
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"sort"
+	"strings"
+
 	"github.com/essentialkaos/perfecto/check"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// DOCS_URL is URL of built-in checks documentation
+const DOCS_URL = "https://kaos.sh/perfecto/w/"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
 // Renderer is interface for perfecto data
 type Renderer interface {
 
@@ -29,4 +37,136 @@ type Renderer interface {
 	Error(file string, err error)
 }
 
+// Flusher is interface for renderers which collect reports for all checked
+// specs and render them as a single document
+type Flusher interface {
+
+	// Flush renders all collected reports
+	Flush() error
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// specResult contains result of spec check for renderers which render all
+// results at once
+type specResult struct {
+	File   string
+	Report *check.Report
+	Err    error
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getStatus returns status of spec check
+func (r *specResult) getStatus() string {
+	switch {
+	case r.Err != nil:
+		return "Error"
+	case r.Report.IsSkipped:
+		return "Skipped"
+	case r.Report.IsPerfect:
+		return "Perfect"
+	}
+
+	return "Has problems"
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getAlerts returns all alerts from report sorted by line
+func getAlerts(report *check.Report) check.Alerts {
+	var result check.Alerts
+
+	result = append(result, report.Notices...)
+	result = append(result, report.Warnings...)
+	result = append(result, report.Errors...)
+	result = append(result, report.Criticals...)
+
+	sort.Stable(result)
+
+	return result
+}
+
+// getLevelName returns name of alert level
+func getLevelName(level uint8) string {
+	switch level {
+	case check.LEVEL_NOTICE:
+		return "notice"
+	case check.LEVEL_WARNING:
+		return "warning"
+	case check.LEVEL_ERROR:
+		return "error"
+	}
+
+	return "critical"
+}
+
+// getLevelTitle returns capitalized name of alert level
+func getLevelTitle(level uint8) string {
+	name := getLevelName(level)
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// getCheckURL returns URL of check documentation or empty string if check
+// isn't built-in
+func getCheckURL(id string) string {
+	if !check.IsBuiltIn(id) {
+		return ""
+	}
+
+	return DOCS_URL + id
+}
+
+// countAlerts returns number of non-ignored alerts
+func countAlerts(alerts check.Alerts) int {
+	return alerts.Total() - alerts.Ignored()
+}
+
+// escapeMarkdown escapes text for using in Markdown document and tables
+func escapeMarkdown(text string) string {
+	var buf strings.Builder
+
+	for _, r := range strings.TrimSpace(text) {
+		switch r {
+		case '\\', '`', '*', '_', '[', ']', '<', '>', '|', '#', '~':
+			buf.WriteRune('\\')
+			buf.WriteRune(r)
+		case '\r':
+			continue
+		case '\n':
+			buf.WriteRune(' ')
+		default:
+			buf.WriteRune(r)
+		}
+	}
+
+	return buf.String()
+}
+
+// formatMarkdownCode returns text formatted as Markdown inline code. Code is
+// wrapped by backticks sequence longer than any sequence inside text.
+func formatMarkdownCode(text string) string {
+	var run, maxRun int
+
+	text = strings.ReplaceAll(text, "|", "\\|")
+
+	for _, r := range text {
+		if r != '`' {
+			run = 0
+			continue
+		}
+
+		run++
+		maxRun = max(maxRun, run)
+	}
+
+	if maxRun == 0 {
+		return "`" + text + "`"
+	}
+
+	fence := strings.Repeat("`", maxRun+1)
+
+	return fence + " " + text + " " + fence
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
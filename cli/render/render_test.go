@@ -0,0 +1,350 @@
+package render
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/essentialkaos/perfecto/check"
+	"github.com/essentialkaos/perfecto/spec"
+
+	chk "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { chk.TestingT(t) }
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+type RenderSuite struct{}
+
+var _ = chk.Suite(&RenderSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *RenderSuite) SetUpSuite(c *chk.C) {
+	check.SetURLProber(nil)
+}
+
+func (s *RenderSuite) TestXMLSchema(c *chk.C) {
+	xmllint, err := exec.LookPath("xmllint")
+
+	if err != nil {
+		c.Skip("xmllint is not installed")
+	}
+
+	report := getTestReport(c, "../../testdata/test_26.spec")
+	dir := c.MkDir()
+
+	single := &XMLRenderer{Version: "1.0.0"}
+	single.Report("test_26.spec", report)
+
+	multi := &XMLRenderer{Version: "1.0.0"}
+	multi.Report("test_26.spec", report)
+	multi.Perfect("test.spec", &check.Report{IsPerfect: true})
+	multi.Skipped("test.spec", &check.Report{IsSkipped: true})
+	multi.Error("test.spec", errors.New("Can't read file"))
+
+	for name, r := range map[string]*XMLRenderer{"single": single, "multi": multi} {
+		file := dir + "/" + name + ".xml"
+		data := captureOutput(c, func() { c.Assert(r.Flush(), chk.IsNil) })
+
+		c.Assert(strings.Count(data, "<?xml"), chk.Equals, 1)
+		c.Assert(os.WriteFile(file, []byte(data), 0644), chk.IsNil)
+
+		output, err := exec.Command(
+			xmllint, "--noout", "--schema", "../../common/perfecto-report.xsd", file,
+		).CombinedOutput()
+
+		c.Assert(err, chk.IsNil, chk.Commentf("%s: %s", name, output))
+	}
+
+	var reports xmlReports
+
+	data := captureOutput(c, func() { multi.Flush() })
+
+	c.Assert(xml.Unmarshal([]byte(data), &reports), chk.IsNil)
+	c.Assert(reports.Reports, chk.HasLen, 4)
+	c.Assert(reports.Reports[3].Error, chk.Equals, "Can't read file")
+
+	c.Assert(captureOutput(c, func() { (&XMLRenderer{}).Flush() }), chk.Equals, "")
+}
+
+func (s *RenderSuite) TestCheckstyleAndJUnit(c *chk.C) {
+	report := getTestReport(c, "../../testdata/test_26.spec")
+	total := report.Total()
+
+	cr := &CheckstyleRenderer{}
+	cr.Report("test_26.spec", report)
+	cr.Perfect("test.spec", &check.Report{IsPerfect: true})
+	cr.Error("broken.spec", errors.New("Can't read file"))
+
+	var checkstyle checkstyleReport
+
+	output := captureOutput(c, func() { c.Assert(cr.Flush(), chk.IsNil) })
+
+	c.Assert(xml.Unmarshal([]byte(output), &checkstyle), chk.IsNil)
+	c.Assert(checkstyle.Files, chk.HasLen, 3)
+	c.Assert(checkstyle.Files[0].Errors, chk.HasLen, total)
+	c.Assert(checkstyle.Files[1].Errors, chk.HasLen, 0)
+	c.Assert(checkstyle.Files[2].Errors[0].Source, chk.Equals, "perfecto")
+
+	jr := &JUnitRenderer{}
+	jr.Report("test_26.spec", report)
+	jr.Skipped("test.spec", &check.Report{IsSkipped: true})
+	jr.Error("broken.spec", errors.New("Can't read file"))
+
+	var junit junitReport
+
+	output = captureOutput(c, func() { c.Assert(jr.Flush(), chk.IsNil) })
+
+	c.Assert(xml.Unmarshal([]byte(output), &junit), chk.IsNil)
+	c.Assert(junit.Suites, chk.HasLen, 3)
+	c.Assert(junit.Errors, chk.Equals, 1)
+	c.Assert(junit.Skipped, chk.Equals, 1)
+	c.Assert(junit.Failures, chk.Not(chk.Equals), 0)
+}
+
+func (s *RenderSuite) TestGitlabFingerprint(c *chk.C) {
+	report := &check.Report{Errors: check.Alerts{
+		check.NewAlert("PF1", check.LEVEL_ERROR, "Test", spec.Line{Index: 10, Text: "rm -rf /opt"}),
+		check.NewAlert("PF1", check.LEVEL_ERROR, "Test", spec.Line{Index: 20, Text: "rm  -rf  /opt"}),
+		check.NewAlert("PF2", check.LEVEL_ERROR, "Test", spec.Line{Index: 30, Text: "rm -rf /opt"}),
+	}}
+
+	issues1 := renderGitlabIssues(c, "test.spec", report)
+
+	c.Assert(issues1, chk.HasLen, 3)
+	c.Assert(issues1[0].Fingerprint, chk.Not(chk.Equals), issues1[1].Fingerprint)
+	c.Assert(issues1[0].Fingerprint, chk.Not(chk.Equals), issues1[2].Fingerprint)
+
+	// Lines are shifted, fingerprints must stay the same
+	for i := range report.Errors {
+		report.Errors[i].Line.Index += 5
+	}
+
+	issues2 := renderGitlabIssues(c, "test.spec", report)
+
+	c.Assert(issues2, chk.HasLen, 3)
+	c.Assert(issues2[0].Location.Lines.Begin, chk.Equals, 15)
+
+	for i := range issues1 {
+		c.Assert(issues2[i].Fingerprint, chk.Equals, issues1[i].Fingerprint)
+	}
+
+	c.Assert(renderGitlabIssues(c, "other.spec", report)[0].Fingerprint, chk.Not(chk.Equals), issues1[0].Fingerprint)
+}
+
+func (s *RenderSuite) TestRDJSONByteColumn(c *chk.C) {
+	for _, tc := range []struct {
+		text   string
+		column int
+		result int
+	}{
+		{"Name: perfecto", 7, 7},
+		{"Summary: Проверка", 10, 10},
+		{"Summary: Проверка", 11, 12},
+		{"Summary: Проверка", 17, 24},
+		{"✓ done", 3, 5},
+		{"日本語 text", 4, 10},
+		{"Name: perfecto", 100, 15},
+		{"", 1, 1},
+	} {
+		c.Assert(getByteColumn(tc.text, tc.column), chk.Equals, tc.result, chk.Commentf("%q:%d", tc.text, tc.column))
+	}
+}
+
+func (s *RenderSuite) TestGithubEscaping(c *chk.C) {
+	for _, tc := range []struct {
+		data     string
+		escaped  string
+		property string
+	}{
+		{"perfecto", "perfecto", "title=perfecto"},
+		{"100% done", "100%25 done", "title=100%25 done"},
+		{"line1\r\nline2", "line1%0D%0Aline2", "title=line1%0D%0Aline2"},
+		{"specs/app.spec:10", "specs/app.spec:10", "title=specs/app.spec%3A10"},
+		{"PF1, PF2", "PF1, PF2", "title=PF1%2C PF2"},
+		{"%0A:,", "%250A:,", "title=%250A%3A%2C"},
+	} {
+		c.Assert(escapeGithubData(tc.data), chk.Equals, tc.escaped)
+		c.Assert(formatGithubProperty("title", tc.data), chk.Equals, tc.property)
+	}
+}
+
+func (s *RenderSuite) TestGithubLimits(c *chk.C) {
+	report := &check.Report{}
+
+	for i := 1; i <= GITHUB_ANNOTATIONS_LIMIT+2; i++ {
+		report.Errors = append(report.Errors, check.NewAlert("PF1", check.LEVEL_ERROR, "Test", spec.Line{Index: i}))
+	}
+
+	for i := 1; i <= GITHUB_ANNOTATIONS_LIMIT; i++ {
+		report.Warnings = append(report.Warnings, check.NewAlert("PF2", check.LEVEL_WARNING, "Test", spec.Line{Index: i}))
+	}
+
+	ignored := check.NewAlert("PF3", check.LEVEL_NOTICE, "Test", spec.Line{Index: 1})
+	ignored.IsIgnored = true
+	report.Notices = append(report.Notices, ignored)
+
+	r := &GithubRenderer{}
+	r.Report("test.spec", report)
+	r.Error("broken.spec", errors.New("Can't read file"))
+	r.calculateLimits()
+
+	// 12 alerts + 1 spec error, so one annotation is reserved for overflow message
+	c.Assert(r.limits["error"], chk.Equals, GITHUB_ANNOTATIONS_LIMIT-1)
+	c.Assert(r.limits["warning"], chk.Equals, GITHUB_ANNOTATIONS_LIMIT)
+	c.Assert(r.limits["notice"], chk.Equals, 0)
+
+	output := captureOutput(c, func() { r.Flush() })
+
+	c.Assert(strings.Count(output, "::error "), chk.Equals, GITHUB_ANNOTATIONS_LIMIT)
+	c.Assert(strings.Count(output, "\nerror: "), chk.Equals, 4)
+	c.Assert(strings.Count(output, "::warning "), chk.Equals, GITHUB_ANNOTATIONS_LIMIT)
+	c.Assert(strings.Count(output, "\nwarning: "), chk.Equals, 0)
+	c.Assert(strings.Count(output, "::notice "), chk.Equals, 0)
+	c.Assert(output, chk.Matches, `(?s).*::error title=perfecto::4 more alerts with level "error" are not shown.*`)
+}
+
+func (s *RenderSuite) TestMarkdownEscaping(c *chk.C) {
+	for _, tc := range []struct {
+		text   string
+		result string
+	}{
+		{"Use %{name} macro", "Use %{name} macro"},
+		{"a | b", "a \\| b"},
+		{"Use `make`", "Use \\`make\\`"},
+		{"*bold* _it_ [link] <b> # ~", "\\*bold\\* \\_it\\_ \\[link\\] \\<b\\> \\# \\~"},
+		{" line1\r\nline2 ", "line1 line2"},
+		{"C:\\dir", "C:\\\\dir"},
+	} {
+		c.Assert(escapeMarkdown(tc.text), chk.Equals, tc.result)
+	}
+
+	for _, tc := range []struct {
+		text   string
+		result string
+	}{
+		{"app.spec", "`app.spec`"},
+		{"a || b", "`a \\|\\| b`"},
+		{"echo `id`", "`` echo `id` ``"},
+		{"a``b | c", "``` a``b \\| c ```"},
+	} {
+		c.Assert(formatMarkdownCode(tc.text), chk.Equals, tc.result)
+	}
+}
+
+func (s *RenderSuite) TestHTML(c *chk.C) {
+	report := getTestReport(c, "../../testdata/test_26.spec")
+
+	r := &HTMLRenderer{Version: "1.0.0"}
+	r.Report("test_26.spec", report)
+	r.Skipped("test.spec", &check.Report{IsSkipped: true})
+	r.Error("broken.spec", errors.New("Can't read <file>"))
+
+	c.Assert(r.files, chk.HasLen, 3)
+	c.Assert(r.files[0].Alerts, chk.Not(chk.HasLen), 0)
+	c.Assert(r.files[0].Alerts[0].Snippet, chk.Not(chk.HasLen), 0)
+	c.Assert(r.files[1].Class, chk.Equals, "skipped")
+	c.Assert(r.files[2].Class, chk.Equals, "error")
+
+	output := captureOutput(c, func() { c.Assert(r.Flush(), chk.IsNil) })
+
+	c.Assert(output, chk.Matches, `(?s)<!DOCTYPE html>.*perfecto 1\.0\.0.*`)
+	c.Assert(output, chk.Matches, `(?s).*<span class="line marked">.*`)
+	c.Assert(output, chk.Matches, `(?s).*Can&#39;t read &lt;file&gt;.*`)
+
+	alert := check.NewAlert("PF1", check.LEVEL_ERROR, "Test", spec.Line{Index: 3, Text: "Name: test"})
+	snippet := r.getSnippet(nil, alert)
+
+	c.Assert(snippet, chk.HasLen, 1)
+	c.Assert(snippet[0].IsMarked, chk.Equals, true)
+}
+
+func (s *RenderSuite) TestTerminalLinks(c *chk.C) {
+	r := &TerminalRenderer{}
+	line := spec.Line{Index: 1, Text: "Name: test"}
+
+	report := &check.Report{Errors: check.Alerts{
+		check.NewAlert("PF1", check.LEVEL_ERROR, "Test", line),
+		check.NewAlert("MY1", check.LEVEL_ERROR, "Test", line),
+	}}
+
+	output := captureOutput(c, func() { r.renderLinks(report) })
+
+	c.Assert(output, chk.Matches, `(?s).*`+DOCS_URL+`PF1.*`)
+	c.Assert(output, chk.Not(chk.Matches), `(?s).*MY1.*`)
+
+	report = &check.Report{Errors: check.Alerts{
+		check.NewAlert("MY1", check.LEVEL_ERROR, "Test", line),
+	}}
+
+	c.Assert(captureOutput(c, func() { r.renderLinks(report) }), chk.Equals, "")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getTestReport returns report for given spec
+func getTestReport(c *chk.C, file string) *check.Report {
+	s, err := spec.Read(file)
+
+	c.Assert(err, chk.IsNil)
+
+	return check.Check(s, false, "", nil)
+}
+
+// captureOutput returns data printed to stdout by given function
+func captureOutput(c *chk.C, f func()) string {
+	r, w, err := os.Pipe()
+
+	c.Assert(err, chk.IsNil)
+
+	output := make(chan []byte)
+
+	// Read data in background, so output bigger than pipe buffer
+	// doesn't block given function
+	go func() {
+		data, _ := io.ReadAll(r)
+		output <- data
+	}()
+
+	stdout := os.Stdout
+	os.Stdout = w
+
+	f()
+
+	os.Stdout = stdout
+	w.Close()
+
+	return string(<-output)
+}
+
+// renderGitlabIssues returns issues rendered by GitLab renderer
+func renderGitlabIssues(c *chk.C, file string, report *check.Report) []*gitlabIssue {
+	var issues []*gitlabIssue
+
+	r := &GitlabRenderer{}
+
+	output := captureOutput(c, func() {
+		r.Report(file, report)
+		r.Flush()
+	})
+
+	c.Assert(json.Unmarshal([]byte(output), &issues), chk.IsNil)
+
+	return issues
+}
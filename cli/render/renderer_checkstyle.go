@@ -0,0 +1,132 @@
+package render
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+
+	"github.com/essentialkaos/perfecto/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// CheckstyleRenderer renders reports in Checkstyle XML format
+type CheckstyleRenderer struct {
+	files []*checkstyleFile
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// checkstyleReport is root element of Checkstyle report
+type checkstyleReport struct {
+	XMLName xml.Name          `xml:"checkstyle"`
+	Version string            `xml:"version,attr"`
+	Files   []*checkstyleFile `xml:"file"`
+}
+
+// checkstyleFile contains alerts for one spec
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+// checkstyleError contains info about one alert
+type checkstyleError struct {
+	Line     int    `xml:"line,attr,omitempty"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Report renders alerts from perfecto report
+func (r *CheckstyleRenderer) Report(file string, report *check.Report) {
+	f := &checkstyleFile{Name: file}
+
+	for _, alert := range getAlerts(report) {
+		e := checkstyleError{
+			Column:   alert.Column,
+			Severity: r.getSeverity(alert),
+			Message:  alert.Info,
+			Source:   "perfecto." + alert.ID,
+		}
+
+		if alert.Line.Index != -1 {
+			e.Line = alert.Line.Index
+		}
+
+		f.Errors = append(f.Errors, e)
+	}
+
+	r.files = append(r.files, f)
+}
+
+// Perfect renders message about perfect spec
+func (r *CheckstyleRenderer) Perfect(file string, report *check.Report) {
+	r.files = append(r.files, &checkstyleFile{Name: file})
+}
+
+// Skipped renders message about skipped check
+func (r *CheckstyleRenderer) Skipped(file string, report *check.Report) {
+	r.files = append(r.files, &checkstyleFile{Name: file})
+}
+
+// Error renders global error message
+func (r *CheckstyleRenderer) Error(file string, err error) {
+	r.files = append(r.files, &checkstyleFile{
+		Name: file,
+		Errors: []checkstyleError{
+			{Severity: "error", Message: err.Error(), Source: "perfecto"},
+		},
+	})
+}
+
+// Flush renders all collected reports
+func (r *CheckstyleRenderer) Flush() error {
+	return encodeXML(&checkstyleReport{Version: "4.3", Files: r.files})
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getSeverity returns Checkstyle severity for given alert
+func (r *CheckstyleRenderer) getSeverity(alert check.Alert) string {
+	switch {
+	case alert.IsIgnored:
+		return "ignore"
+	case alert.Level == check.LEVEL_NOTICE:
+		return "info"
+	case alert.Level == check.LEVEL_WARNING:
+		return "warning"
+	}
+
+	return "error"
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// encodeXML prints given data as indented XML document
+func encodeXML(v any) error {
+	enc := xml.NewEncoder(os.Stdout)
+	enc.Indent("", "  ")
+
+	fmt.Print(xml.Header)
+
+	err := enc.Encode(v)
+
+	if err != nil {
+		return fmt.Errorf("Can't encode report: %w", err)
+	}
+
+	fmt.Println()
+
+	return nil
+}
@@ -9,9 +9,13 @@ package render
 
 import (
 	"fmt"
+	"os"
+	"slices"
+	"strings"
 
 	"github.com/essentialkaos/ek/v13/fmtc"
 	"github.com/essentialkaos/ek/v13/path"
+	"github.com/essentialkaos/ek/v13/sortutil"
 	"github.com/essentialkaos/ek/v13/strutil"
 
 	"github.com/essentialkaos/perfecto/check"
@@ -19,68 +23,291 @@ import (
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// GITHUB_ANNOTATIONS_LIMIT is maximum number of annotations per level shown
+// by GitHub for one step
+const GITHUB_ANNOTATIONS_LIMIT = 10
+
+// GITHUB_TOP_CHECKS is number of checks shown in job summary
+const GITHUB_TOP_CHECKS = 3
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
 // GithubRenderer renders report using github actions workflow commands
-type GithubRenderer struct{}
+type GithubRenderer struct {
+	results []*specResult
+	limits  map[string]int
+	counts  map[string]int
+}
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // Report renders alerts from perfecto report
 func (r *GithubRenderer) Report(file string, report *check.Report) {
-	if report.Notices.Total() != 0 {
-		r.renderActionAlerts("notice", file, report.Notices)
-	}
-
-	if report.Warnings.Total() != 0 {
-		r.renderActionAlerts("warning", file, report.Warnings)
-	}
-
-	if report.Errors.Total() != 0 {
-		r.renderActionAlerts("error", file, report.Errors)
-	}
-
-	if report.Criticals.Total() != 0 {
-		r.renderActionAlerts("error", file, report.Criticals)
-	}
+	r.results = append(r.results, &specResult{File: file, Report: report})
 }
 
 // Perfect renders message about perfect spec
 func (r *GithubRenderer) Perfect(file string, report *check.Report) {
-	specName := strutil.Exclude(path.Base(file), ".spec")
-	fmtc.Printf("{g}{*}%s.spec{!*} is perfect!{!}\n", specName)
+	r.results = append(r.results, &specResult{File: file, Report: report})
 }
 
 // Skipped renders message about skipped check
 func (r *GithubRenderer) Skipped(file string, report *check.Report) {
-	specName := strutil.Exclude(path.Base(file), ".spec")
-	fmtc.Printf("{s}{*}%s.spec{!*} check skipped due to non-applicable target{!}\n", specName)
+	r.results = append(r.results, &specResult{File: file, Report: report})
 }
 
 // Error renders global error message
 func (r *GithubRenderer) Error(file string, err error) {
-	fmt.Printf("::error file=%s::%v\n", file, err)
+	r.results = append(r.results, &specResult{File: file, Err: err})
+}
+
+// Flush renders all collected reports
+func (r *GithubRenderer) Flush() error {
+	r.calculateLimits()
+
+	for _, result := range r.results {
+		fmt.Printf("::group::%s\n", escapeGithubData(result.File))
+		r.renderResult(result)
+		fmt.Println("::endgroup::")
+	}
+
+	r.renderOverflow()
+	r.renderSummary()
+
+	return nil
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-// renderActionAlerts renders alert with control commands
-func (r *GithubRenderer) renderActionAlerts(level, file string, alerts []check.Alert) {
-	for _, alert := range alerts {
-		title := "Global"
+// calculateLimits calculates number of annotations which can be shown for
+// every level. If there are more alerts than GitHub can show, one annotation is
+// reserved for overflow message.
+func (r *GithubRenderer) calculateLimits() {
+	r.limits = map[string]int{}
+	r.counts = map[string]int{}
 
-		if alert.ID != "" {
-			title = alert.ID
+	for _, result := range r.results {
+		if result.Err != nil {
+			r.limits["error"]++
+			continue
 		}
 
-		if alert.Line.Index == -1 {
-			fmt.Printf(
-				"::%s file=%s,title=%s::%s\n",
-				level, file, title, alert.Info,
-			)
-		} else {
-			fmt.Printf(
-				"::%s file=%s,line=%d,title=%s::%s\n",
-				level, file, alert.Line.Index, title, alert.Info,
-			)
+		for _, alert := range getAlerts(result.Report) {
+			if !alert.IsIgnored {
+				r.limits[r.getAnnotationLevel(alert.Level)]++
+			}
 		}
 	}
+
+	for level, total := range r.limits {
+		if total > GITHUB_ANNOTATIONS_LIMIT {
+			r.limits[level] = GITHUB_ANNOTATIONS_LIMIT - 1
+		}
+	}
+}
+
+// renderResult renders result of spec check
+func (r *GithubRenderer) renderResult(result *specResult) {
+	specName := strutil.Exclude(path.Base(result.File), ".spec")
+
+	switch {
+	case result.Err != nil:
+		r.renderAnnotation(
+			"error", formatGithubProperty("file", result.File),
+			result.File, result.Err.Error(),
+		)
+	case result.Report.IsSkipped:
+		fmtc.Printf("{s}{*}%s.spec{!*} check skipped due to non-applicable target{!}\n", specName)
+	case result.Report.IsPerfect:
+		fmtc.Printf("{g}{*}%s.spec{!*} is perfect!{!}\n", specName)
+	default:
+		for _, alert := range getAlerts(result.Report) {
+			if !alert.IsIgnored {
+				r.renderAlert(result.File, alert)
+			}
+		}
+	}
+}
+
+// renderAlert renders alert as annotation
+func (r *GithubRenderer) renderAlert(file string, alert check.Alert) {
+	title := "Global"
+
+	if alert.ID != "" {
+		title = alert.ID
+	}
+
+	props := formatGithubProperty("file", file)
+	location := file
+
+	if alert.Line.Index != -1 {
+		location += fmt.Sprintf(":%d", alert.Line.Index)
+	}
+
+	switch {
+	case alert.Line.Index == -1:
+		// Global alert without position
+	case alert.EndLine != 0:
+		props += fmt.Sprintf(",line=%d,endLine=%d", alert.Line.Index, alert.EndLine)
+	case alert.HasSpan():
+		props += fmt.Sprintf(
+			",line=%d,col=%d,endColumn=%d",
+			alert.Line.Index, alert.Column, alert.EndColumn,
+		)
+	default:
+		props += fmt.Sprintf(",line=%d", alert.Line.Index)
+	}
+
+	props += "," + formatGithubProperty("title", title)
+
+	r.renderAnnotation(r.getAnnotationLevel(alert.Level), props, location, alert.Info)
+}
+
+// renderAnnotation renders annotation with given level or plain message if
+// annotations limit is reached
+func (r *GithubRenderer) renderAnnotation(level, props, location, message string) {
+	r.counts[level]++
+
+	if r.counts[level] > r.limits[level] {
+		fmt.Printf("%s: %s: %s\n", level, location, message)
+		return
+	}
+
+	fmt.Printf("::%s %s::%s\n", level, props, escapeGithubData(message))
+}
+
+// renderOverflow renders annotations with number of alerts which are not shown
+// as annotations
+func (r *GithubRenderer) renderOverflow() {
+	for _, level := range []string{"error", "warning", "notice"} {
+		overflow := r.counts[level] - r.limits[level]
+
+		if overflow <= 0 {
+			continue
+		}
+
+		fmt.Printf(
+			"::%s %s::%s\n", level, formatGithubProperty("title", "perfecto"),
+			escapeGithubData(fmt.Sprintf(
+				"%d more alerts with level %q are not shown as annotations, see job log for details",
+				overflow, level,
+			)),
+		)
+	}
+}
+
+// renderSummary appends Markdown table with results to job summary
+func (r *GithubRenderer) renderSummary() {
+	summaryFile := os.Getenv("GITHUB_STEP_SUMMARY")
+
+	if summaryFile == "" {
+		return
+	}
+
+	fd, err := os.OpenFile(summaryFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+
+	if err != nil {
+		return
+	}
+
+	defer fd.Close()
+
+	fmt.Fprint(fd, "### Perfecto\n\n")
+	fmt.Fprintln(fd, "| Spec | Status | Notices | Warnings | Errors | Criticals | Top checks |")
+	fmt.Fprintln(fd, "|------|--------|--------:|---------:|-------:|----------:|------------|")
+
+	for _, result := range r.results {
+		fmt.Fprintln(fd, r.formatSummaryRow(result))
+	}
+
+	fmt.Fprintln(fd)
+}
+
+// formatSummaryRow returns row of job summary table for given result
+func (r *GithubRenderer) formatSummaryRow(result *specResult) string {
+	file := formatMarkdownCode(result.File)
+
+	switch {
+	case result.Err != nil:
+		return fmt.Sprintf(
+			"| %s | Error: %s | – | – | – | – | – |",
+			file, escapeMarkdown(result.Err.Error()),
+		)
+	case result.Report.IsSkipped:
+		return fmt.Sprintf("| %s | Skipped | – | – | – | – | – |", file)
+	}
+
+	return fmt.Sprintf(
+		"| %s | %s | %d | %d | %d | %d | %s |", file, result.getStatus(),
+		countAlerts(result.Report.Notices), countAlerts(result.Report.Warnings),
+		countAlerts(result.Report.Errors), countAlerts(result.Report.Criticals),
+		r.formatTopChecks(result.Report),
+	)
+}
+
+// formatTopChecks returns checks with the biggest number of alerts
+func (r *GithubRenderer) formatTopChecks(report *check.Report) string {
+	stats := map[string]int{}
+
+	for _, alert := range getAlerts(report) {
+		if !alert.IsIgnored {
+			stats[alert.ID]++
+		}
+	}
+
+	if len(stats) == 0 {
+		return "–"
+	}
+
+	var ids []string
+
+	for id := range stats {
+		ids = append(ids, id)
+	}
+
+	sortutil.StringsNatural(ids)
+
+	slices.SortStableFunc(ids, func(a, b string) int {
+		return stats[b] - stats[a]
+	})
+
+	var result []string
+
+	for _, id := range ids[:min(len(ids), GITHUB_TOP_CHECKS)] {
+		result = append(result, fmt.Sprintf("`%s` (%d)", id, stats[id]))
+	}
+
+	return strings.Join(result, ", ")
+}
+
+// getAnnotationLevel returns level of annotation for given alert level
+func (r *GithubRenderer) getAnnotationLevel(level uint8) string {
+	switch level {
+	case check.LEVEL_NOTICE:
+		return "notice"
+	case check.LEVEL_WARNING:
+		return "warning"
+	}
+
+	return "error"
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// formatGithubProperty returns workflow command property with escaped value
+func formatGithubProperty(name, value string) string {
+	value = escapeGithubData(value)
+	value = strings.ReplaceAll(value, ":", "%3A")
+	value = strings.ReplaceAll(value, ",", "%2C")
+
+	return name + "=" + value
+}
+
+// escapeGithubData escapes data of workflow command
+func escapeGithubData(data string) string {
+	data = strings.ReplaceAll(data, "%", "%25")
+	data = strings.ReplaceAll(data, "\r", "%0D")
+	data = strings.ReplaceAll(data, "\n", "%0A")
+
+	return data
 }
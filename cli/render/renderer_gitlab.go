@@ -0,0 +1,146 @@
+package render
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/essentialkaos/perfecto/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// GitlabRenderer renders reports in GitLab Code Quality format
+type GitlabRenderer struct {
+	issues       []*gitlabIssue
+	fingerprints map[string]int
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// gitlabIssue contains info about one Code Quality issue
+type gitlabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    gitlabLocation `json:"location"`
+}
+
+// gitlabLocation contains location of issue
+type gitlabLocation struct {
+	Path  string      `json:"path"`
+	Lines gitlabLines `json:"lines"`
+}
+
+// gitlabLines contains lines of issue
+type gitlabLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end,omitempty"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Report renders alerts from perfecto report
+func (r *GitlabRenderer) Report(file string, report *check.Report) {
+	for _, alert := range getAlerts(report) {
+		if alert.IsIgnored {
+			continue
+		}
+
+		issue := &gitlabIssue{
+			Description: alert.Info,
+			CheckName:   alert.ID,
+			Fingerprint: r.getFingerprint(file, alert.ID, alert.Line.Text),
+			Severity:    r.getSeverity(alert.Level),
+			Location:    gitlabLocation{Path: file, Lines: gitlabLines{Begin: 1}},
+		}
+
+		if alert.Line.Index > 0 {
+			issue.Location.Lines.Begin = alert.Line.Index
+		}
+
+		if alert.EndLine != 0 {
+			issue.Location.Lines.End = alert.EndLine
+		}
+
+		r.issues = append(r.issues, issue)
+	}
+}
+
+// Perfect renders message about perfect spec
+func (r *GitlabRenderer) Perfect(file string, report *check.Report) {}
+
+// Skipped renders message about skipped check
+func (r *GitlabRenderer) Skipped(file string, report *check.Report) {}
+
+// Error renders global error message
+func (r *GitlabRenderer) Error(file string, err error) {
+	r.issues = append(r.issues, &gitlabIssue{
+		Description: err.Error(),
+		CheckName:   "perfecto",
+		Fingerprint: r.getFingerprint(file, "perfecto", ""),
+		Severity:    "blocker",
+		Location:    gitlabLocation{Path: file, Lines: gitlabLines{Begin: 1}},
+	})
+}
+
+// Flush renders all collected reports
+func (r *GitlabRenderer) Flush() error {
+	issues := r.issues
+
+	if issues == nil {
+		issues = []*gitlabIssue{}
+	}
+
+	data, _ := json.MarshalIndent(issues, "", "  ")
+	fmt.Println(string(data))
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getFingerprint returns fingerprint of issue based on file, check ID and
+// normalized line text. Fingerprint doesn't depend on line number, so it stays
+// the same if lines are added or removed above the problem.
+func (r *GitlabRenderer) getFingerprint(file, id, text string) string {
+	if r.fingerprints == nil {
+		r.fingerprints = map[string]int{}
+	}
+
+	key := file + "\x00" + id + "\x00" + strings.Join(strings.Fields(text), " ")
+	num := r.fingerprints[key]
+
+	r.fingerprints[key]++
+
+	// Issues with the same key must have unique fingerprints, so we add
+	// the number of occurrence to all duplicates
+	if num != 0 {
+		key += fmt.Sprintf("\x00%d", num)
+	}
+
+	return fmt.Sprintf("%x", md5.Sum([]byte(key)))
+}
+
+// getSeverity returns Code Quality severity for given alert level
+func (r *GitlabRenderer) getSeverity(level uint8) string {
+	switch level {
+	case check.LEVEL_NOTICE:
+		return "info"
+	case check.LEVEL_WARNING:
+		return "minor"
+	case check.LEVEL_ERROR:
+		return "major"
+	}
+
+	return "critical"
+}
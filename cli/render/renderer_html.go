@@ -0,0 +1,218 @@
+package render
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/perfecto/check"
+	"github.com/essentialkaos/perfecto/spec"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// HTML_CONTEXT is number of lines shown before and after line with problem
+const HTML_CONTEXT = 2
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// HTMLRenderer renders reports as standalone HTML page
+type HTMLRenderer struct {
+	Version string // Version of perfecto
+
+	files []*htmlFile
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// htmlReport contains data for HTML template
+type htmlReport struct {
+	Version string
+	Date    string
+	Files   []*htmlFile
+	Counts  htmlCounts
+}
+
+// htmlFile contains info about checked spec
+type htmlFile struct {
+	Name   string
+	Status string
+	Class  string
+	Error  string
+	Counts htmlCounts
+	Alerts []*htmlAlert
+}
+
+// htmlCounts contains numbers of non-ignored alerts by level and number of
+// ignored alerts
+type htmlCounts struct {
+	Notices   int
+	Warnings  int
+	Errors    int
+	Criticals int
+	Ignored   int
+}
+
+// htmlAlert contains info about alert
+type htmlAlert struct {
+	ID        string
+	URL       string
+	Level     string
+	Title     string
+	Info      string
+	Fix       string
+	Position  string
+	IsIgnored bool
+	Snippet   []htmlLine
+}
+
+// htmlLine contains line of source snippet
+type htmlLine struct {
+	Index    int
+	Text     string
+	IsMarked bool
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// htmlTemplate is template of HTML report
+var htmlTemplate = template.Must(template.New("report").Parse(htmlTemplateData))
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Report renders alerts from perfecto report
+func (r *HTMLRenderer) Report(file string, report *check.Report) {
+	r.files = append(r.files, r.convertReport(file, report))
+}
+
+// Perfect renders message about perfect spec
+func (r *HTMLRenderer) Perfect(file string, report *check.Report) {
+	r.files = append(r.files, r.convertReport(file, report))
+}
+
+// Skipped renders message about skipped check
+func (r *HTMLRenderer) Skipped(file string, report *check.Report) {
+	r.files = append(r.files, r.convertReport(file, report))
+}
+
+// Error renders global error message
+func (r *HTMLRenderer) Error(file string, err error) {
+	result := &specResult{File: file, Err: err}
+
+	r.files = append(r.files, &htmlFile{
+		Name:   file,
+		Status: result.getStatus(),
+		Class:  getHTMLClass(result.getStatus()),
+		Error:  err.Error(),
+	})
+}
+
+// Flush renders all collected reports
+func (r *HTMLRenderer) Flush() error {
+	report := &htmlReport{
+		Version: r.Version,
+		Date:    time.Now().Format(time.DateTime),
+		Files:   r.files,
+	}
+
+	for _, f := range r.files {
+		report.Counts.Notices += f.Counts.Notices
+		report.Counts.Warnings += f.Counts.Warnings
+		report.Counts.Errors += f.Counts.Errors
+		report.Counts.Criticals += f.Counts.Criticals
+		report.Counts.Ignored += f.Counts.Ignored
+	}
+
+	err := htmlTemplate.Execute(os.Stdout, report)
+
+	if err != nil {
+		return fmt.Errorf("Can't render HTML report: %w", err)
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// convertReport converts perfecto report to HTML template data
+func (r *HTMLRenderer) convertReport(file string, report *check.Report) *htmlFile {
+	result := &specResult{File: file, Report: report}
+	source := report.Spec
+
+	f := &htmlFile{
+		Name:   file,
+		Status: result.getStatus(),
+		Class:  getHTMLClass(result.getStatus()),
+		Counts: htmlCounts{
+			Notices:   countAlerts(report.Notices),
+			Warnings:  countAlerts(report.Warnings),
+			Errors:    countAlerts(report.Errors),
+			Criticals: countAlerts(report.Criticals),
+			Ignored:   report.Ignored(),
+		},
+	}
+
+	for _, alert := range getAlerts(report) {
+		a := &htmlAlert{
+			ID:        alert.ID,
+			URL:       getCheckURL(alert.ID),
+			Level:     getLevelName(alert.Level),
+			Title:     getLevelTitle(alert.Level),
+			Info:      alert.Info,
+			Fix:       alert.Fix,
+			Position:  "global",
+			IsIgnored: alert.IsIgnored,
+		}
+
+		if alert.Line.Index != -1 {
+			a.Position = "line " + strconv.Itoa(alert.Line.Index)
+			a.Snippet = r.getSnippet(source, alert)
+		}
+
+		f.Alerts = append(f.Alerts, a)
+	}
+
+	return f
+}
+
+// getSnippet returns lines of spec around line with problem
+func (r *HTMLRenderer) getSnippet(source *spec.Spec, alert check.Alert) []htmlLine {
+	if source == nil {
+		return []htmlLine{{alert.Line.Index, alert.Line.Text, true}}
+	}
+
+	var result []htmlLine
+
+	start, end := alert.Line.Index, max(alert.Line.Index, alert.EndLine)
+
+	for _, line := range source.Data {
+		if line.Index < start-HTML_CONTEXT || line.Index > end+HTML_CONTEXT {
+			continue
+		}
+
+		result = append(result, htmlLine{
+			Index:    line.Index,
+			Text:     line.Text,
+			IsMarked: line.Index >= start && line.Index <= end,
+		})
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getHTMLClass returns CSS class name for given text
+func getHTMLClass(text string) string {
+	return strings.ReplaceAll(strings.ToLower(text), " ", "-")
+}
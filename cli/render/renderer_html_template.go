@@ -0,0 +1,126 @@
+package render
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// htmlTemplateData is source of HTML report template
+const htmlTemplateData = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <meta name="generator" content="perfecto {{.Version}}">
+  <title>Perfecto report</title>
+  <style>
+    :root {
+      --notice: #0a8ea0; --warning: #c48a00; --error: #d9480f; --critical: #c92a2a;
+      --perfect: #2b8a3e; --muted: #868e96; --border: #dee2e6; --bg: #f8f9fa;
+    }
+    * { box-sizing: border-box; }
+    body { margin: 0; padding: 24px; font: 14px/1.5 -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #212529; }
+    h1 { margin: 0 0 4px; font-size: 24px; }
+    a { color: inherit; }
+    code, pre { font: 13px/1.45 ui-monospace, SFMono-Regular, Menlo, Consolas, monospace; }
+    .meta { margin: 0 0 16px; color: var(--muted); }
+    .toolbar { display: flex; flex-wrap: wrap; gap: 16px; align-items: center; margin-bottom: 16px; padding: 12px; background: var(--bg); border: 1px solid var(--border); border-radius: 6px; }
+    .toolbar label { cursor: pointer; user-select: none; }
+    .count { display: inline-block; min-width: 24px; padding: 0 6px; border-radius: 10px; color: #fff; font-size: 12px; font-weight: bold; text-align: center; }
+    .count.notice { background: var(--notice); }
+    .count.warning { background: var(--warning); }
+    .count.error { background: var(--error); }
+    .count.critical { background: var(--critical); }
+    .count.ignored { background: var(--muted); }
+    details.file { margin-bottom: 8px; border: 1px solid var(--border); border-radius: 6px; }
+    details.file > summary { display: flex; gap: 12px; align-items: center; padding: 8px 12px; background: var(--bg); cursor: pointer; }
+    details.file[open] > summary { border-bottom: 1px solid var(--border); }
+    summary .name { flex: 1; font-weight: bold; font-family: ui-monospace, SFMono-Regular, Menlo, Consolas, monospace; }
+    summary .status { font-size: 12px; text-transform: uppercase; color: var(--muted); }
+    .file.has-problems summary .status, .file.error summary .status { color: var(--error); }
+    .file.perfect summary .status { color: var(--perfect); }
+    .file .message { margin: 0; padding: 12px; color: var(--muted); }
+    .file.error .message { color: var(--error); }
+    .alert { padding: 10px 12px; border-left: 4px solid var(--border); }
+    .alert + .alert { border-top: 1px solid var(--border); }
+    .alert.notice { border-left-color: var(--notice); }
+    .alert.warning { border-left-color: var(--warning); }
+    .alert.error { border-left-color: var(--error); }
+    .alert.critical { border-left-color: var(--critical); }
+    .alert.ignored { opacity: .55; }
+    .alert .level { font-weight: bold; }
+    .alert.notice .level { color: var(--notice); }
+    .alert.warning .level { color: var(--warning); }
+    .alert.error .level { color: var(--error); }
+    .alert.critical .level { color: var(--critical); }
+    .alert .position, .alert .tag { color: var(--muted); font-size: 12px; }
+    .snippet { margin: 8px 0 0; padding: 6px 0; overflow-x: auto; background: var(--bg); border-radius: 4px; }
+    .snippet .line { display: block; padding: 0 12px 0 0; white-space: pre; }
+    .snippet .line.marked { background: #fff3bf; }
+    .snippet .num { display: inline-block; width: 56px; margin-right: 12px; padding-right: 8px; text-align: right; color: var(--muted); border-right: 1px solid var(--border); user-select: none; }
+    .fix { margin-top: 6px; }
+    .fix code { padding: 1px 4px; background: #ebfbee; border-radius: 3px; white-space: pre; }
+    body.hide-notice .alert.notice, body.hide-warning .alert.warning, body.hide-error .alert.error,
+    body.hide-critical .alert.critical, body.hide-ignored .alert.ignored { display: none; }
+  </style>
+</head>
+<body>
+  <h1>Perfecto report</h1>
+  <p class="meta">Generated {{.Date}} by perfecto {{.Version}} • Specs: {{len .Files}}</p>
+  <div class="toolbar">
+    <label><input type="checkbox" data-filter="notice" checked> Notices <span class="count notice">{{.Counts.Notices}}</span></label>
+    <label><input type="checkbox" data-filter="warning" checked> Warnings <span class="count warning">{{.Counts.Warnings}}</span></label>
+    <label><input type="checkbox" data-filter="error" checked> Errors <span class="count error">{{.Counts.Errors}}</span></label>
+    <label><input type="checkbox" data-filter="critical" checked> Criticals <span class="count critical">{{.Counts.Criticals}}</span></label>
+    <label><input type="checkbox" data-filter="ignored" checked> Ignored <span class="count ignored">{{.Counts.Ignored}}</span></label>
+  </div>
+  {{- range .Files}}
+  <details class="file {{.Class}}"{{if .Alerts}} open{{end}}>
+    <summary>
+      <span class="name">{{.Name}}</span>
+      {{- if .Alerts}}
+      <span class="count notice" title="Notices">{{.Counts.Notices}}</span>
+      <span class="count warning" title="Warnings">{{.Counts.Warnings}}</span>
+      <span class="count error" title="Errors">{{.Counts.Errors}}</span>
+      <span class="count critical" title="Criticals">{{.Counts.Criticals}}</span>
+      {{- end}}
+      <span class="status">{{.Status}}</span>
+    </summary>
+    {{- if .Error}}
+    <p class="message">{{.Error}}</p>
+    {{- else if eq .Class "skipped"}}
+    <p class="message">Check skipped due to non-applicable target</p>
+    {{- else if not .Alerts}}
+    <p class="message">Spec is perfect!</p>
+    {{- end}}
+    {{- range .Alerts}}
+    <div class="alert {{.Level}}{{if .IsIgnored}} ignored{{end}}">
+      <div>
+        <span class="level">{{.Title}}</span>
+        <code>{{if .URL}}<a href="{{.URL}}" target="_blank" rel="noopener">{{.ID}}</a>{{else}}{{.ID}}{{end}}</code>
+        {{.Info}}
+        <span class="position">({{.Position}})</span>
+        {{- if .IsIgnored}} <span class="tag">ignored</span>{{end}}
+      </div>
+      {{- if .Snippet}}
+      <pre class="snippet">{{range .Snippet}}<span class="line{{if .IsMarked}} marked{{end}}"><span class="num">{{.Index}}</span>{{.Text}}</span>{{end}}</pre>
+      {{- end}}
+      {{- if .Fix}}
+      <div class="fix">Fix: <code>{{.Fix}}</code></div>
+      {{- end}}
+    </div>
+    {{- end}}
+  </details>
+  {{- end}}
+  <script>
+    document.querySelectorAll("[data-filter]").forEach(function(input) {
+      input.addEventListener("change", function() {
+        document.body.classList.toggle("hide-" + input.dataset.filter, !input.checked);
+      });
+    });
+  </script>
+</body>
+</html>
+`
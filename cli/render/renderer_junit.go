@@ -0,0 +1,182 @@
+package render
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/xml"
+	"fmt"
+	"slices"
+
+	"github.com/essentialkaos/ek/v13/path"
+	"github.com/essentialkaos/ek/v13/sortutil"
+	"github.com/essentialkaos/ek/v13/strutil"
+
+	"github.com/essentialkaos/perfecto/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// JUnitRenderer renders reports in JUnit XML format
+type JUnitRenderer struct {
+	suites []*junitSuite
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// junitReport is root element of JUnit report
+type junitReport struct {
+	XMLName  xml.Name      `xml:"testsuites"`
+	Name     string        `xml:"name,attr"`
+	Tests    int           `xml:"tests,attr"`
+	Failures int           `xml:"failures,attr"`
+	Errors   int           `xml:"errors,attr"`
+	Skipped  int           `xml:"skipped,attr"`
+	Suites   []*junitSuite `xml:"testsuite"`
+}
+
+// junitSuite contains results of checks for one spec
+type junitSuite struct {
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Errors   int          `xml:"errors,attr"`
+	Skipped  int          `xml:"skipped,attr"`
+	Cases    []*junitCase `xml:"testcase"`
+}
+
+// junitCase contains result of one check
+type junitCase struct {
+	Name      string         `xml:"name,attr"`
+	ClassName string         `xml:"classname,attr"`
+	Failures  []junitFailure `xml:"failure"`
+	Error     *junitMessage  `xml:"error"`
+	Skipped   *junitMessage  `xml:"skipped"`
+}
+
+// junitFailure contains info about one alert
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// junitMessage contains message of error or skipped test
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Report renders alerts from perfecto report
+func (r *JUnitRenderer) Report(file string, report *check.Report) {
+	suite := &junitSuite{Name: file}
+	alerts := getAlerts(report)
+	ids := append(slices.Clone(report.Checks), report.IDs()...)
+
+	sortutil.StringsNatural(ids)
+
+	for _, id := range slices.Compact(ids) {
+		tc := &junitCase{Name: id, ClassName: getClassName(file)}
+
+		var ignored int
+
+		for _, alert := range alerts {
+			switch {
+			case alert.ID != id:
+				continue
+			case alert.IsIgnored:
+				ignored++
+				continue
+			}
+
+			tc.Failures = append(tc.Failures, junitFailure{
+				Message: alert.Info,
+				Type:    getLevelName(alert.Level),
+				Text:    r.formatLocation(file, alert),
+			})
+		}
+
+		switch {
+		case len(tc.Failures) != 0:
+			suite.Failures++
+		case ignored != 0:
+			tc.Skipped = &junitMessage{"All alerts are ignored"}
+			suite.Skipped++
+		}
+
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	suite.Tests = len(suite.Cases)
+
+	r.suites = append(r.suites, suite)
+}
+
+// Perfect renders message about perfect spec
+func (r *JUnitRenderer) Perfect(file string, report *check.Report) {
+	r.Report(file, report)
+}
+
+// Skipped renders message about skipped check
+func (r *JUnitRenderer) Skipped(file string, report *check.Report) {
+	r.suites = append(r.suites, &junitSuite{
+		Name: file, Tests: 1, Skipped: 1,
+		Cases: []*junitCase{{
+			Name:      "perfecto",
+			ClassName: getClassName(file),
+			Skipped:   &junitMessage{"Check skipped due to non-applicable target"},
+		}},
+	})
+}
+
+// Error renders global error message
+func (r *JUnitRenderer) Error(file string, err error) {
+	r.suites = append(r.suites, &junitSuite{
+		Name: file, Tests: 1, Errors: 1,
+		Cases: []*junitCase{{
+			Name:      "perfecto",
+			ClassName: getClassName(file),
+			Error:     &junitMessage{err.Error()},
+		}},
+	})
+}
+
+// Flush renders all collected reports
+func (r *JUnitRenderer) Flush() error {
+	report := &junitReport{Name: "perfecto", Suites: r.suites}
+
+	for _, suite := range r.suites {
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Skipped += suite.Skipped
+	}
+
+	return encodeXML(report)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// formatLocation returns location of alert and text of spec line
+func (r *JUnitRenderer) formatLocation(file string, alert check.Alert) string {
+	switch {
+	case alert.Line.Index == -1:
+		return file
+	case alert.Column != 0:
+		return fmt.Sprintf("%s:%d:%d: %s", file, alert.Line.Index, alert.Column, alert.Line.Text)
+	}
+
+	return fmt.Sprintf("%s:%d: %s", file, alert.Line.Index, alert.Line.Text)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getClassName returns name of test class for given spec
+func getClassName(file string) string {
+	return "perfecto." + strutil.Exclude(path.Base(file), ".spec")
+}
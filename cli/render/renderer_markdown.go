@@ -0,0 +1,137 @@
+package render
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+
+	"github.com/essentialkaos/perfecto/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// MarkdownRenderer renders reports in Markdown format
+type MarkdownRenderer struct {
+	results []*specResult
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Report renders alerts from perfecto report
+func (r *MarkdownRenderer) Report(file string, report *check.Report) {
+	r.results = append(r.results, &specResult{File: file, Report: report})
+}
+
+// Perfect renders message about perfect spec
+func (r *MarkdownRenderer) Perfect(file string, report *check.Report) {
+	r.results = append(r.results, &specResult{File: file, Report: report})
+}
+
+// Skipped renders message about skipped check
+func (r *MarkdownRenderer) Skipped(file string, report *check.Report) {
+	r.results = append(r.results, &specResult{File: file, Report: report})
+}
+
+// Error renders global error message
+func (r *MarkdownRenderer) Error(file string, err error) {
+	r.results = append(r.results, &specResult{File: file, Err: err})
+}
+
+// Flush renders all collected reports
+func (r *MarkdownRenderer) Flush() error {
+	fmt.Println("## Perfecto report")
+
+	if len(r.results) > 1 {
+		r.renderSummary()
+	}
+
+	for _, result := range r.results {
+		r.renderResult(result)
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// renderSummary renders table with results of all specs
+func (r *MarkdownRenderer) renderSummary() {
+	fmt.Println()
+	fmt.Println("| Spec | Status | Notices | Warnings | Errors | Criticals |")
+	fmt.Println("|------|--------|--------:|---------:|-------:|----------:|")
+
+	for _, result := range r.results {
+		if result.Err != nil || result.Report.IsSkipped {
+			fmt.Printf(
+				"| %s | %s | – | – | – | – |\n",
+				formatMarkdownCode(result.File), result.getStatus(),
+			)
+			continue
+		}
+
+		fmt.Printf(
+			"| %s | %s | %d | %d | %d | %d |\n",
+			formatMarkdownCode(result.File), result.getStatus(),
+			countAlerts(result.Report.Notices), countAlerts(result.Report.Warnings),
+			countAlerts(result.Report.Errors), countAlerts(result.Report.Criticals),
+		)
+	}
+}
+
+// renderResult renders result of spec check
+func (r *MarkdownRenderer) renderResult(result *specResult) {
+	fmt.Printf("\n### %s\n\n", formatMarkdownCode(result.File))
+
+	switch {
+	case result.Err != nil:
+		fmt.Printf("**Error:** %s\n", escapeMarkdown(result.Err.Error()))
+		return
+	case result.Report.IsSkipped:
+		fmt.Println("Check skipped due to non-applicable target.")
+		return
+	case result.Report.IsPerfect && result.Report.Total() == 0:
+		fmt.Println("Spec is perfect!")
+		return
+	}
+
+	fmt.Println("| Line | Level | Check | Message |")
+	fmt.Println("|-----:|-------|-------|---------|")
+
+	for _, alert := range getAlerts(result.Report) {
+		r.renderAlert(alert)
+	}
+}
+
+// renderAlert renders alert as table row
+func (r *MarkdownRenderer) renderAlert(alert check.Alert) {
+	line, id := "–", escapeMarkdown(alert.ID)
+	message := escapeMarkdown(alert.Info)
+	level := getLevelTitle(alert.Level)
+
+	if alert.Line.Index != -1 {
+		line = fmt.Sprintf("%d", alert.Line.Index)
+	}
+
+	if alert.EndLine != 0 {
+		line += fmt.Sprintf("–%d", alert.EndLine)
+	}
+
+	if getCheckURL(alert.ID) != "" {
+		id = fmt.Sprintf("[%s](%s)", alert.ID, getCheckURL(alert.ID))
+	}
+
+	if alert.Fix != "" {
+		message += "<br>Fix: " + formatMarkdownCode(alert.Fix)
+	}
+
+	if alert.IsIgnored {
+		message = "~~" + message + "~~ _(ignored)_"
+	}
+
+	fmt.Printf("| %s | %s | %s | %s |\n", line, level, id, message)
+}
@@ -0,0 +1,220 @@
+package render
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/essentialkaos/perfecto/check"
+	"github.com/essentialkaos/perfecto/spec"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// RDJSONRenderer renders reports in reviewdog diagnostic format
+type RDJSONRenderer struct {
+	Lines bool // Render every diagnostic as a separate JSON document (rdjsonl)
+
+	diagnostics []*rdDiagnostic
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// rdResult is root element of rdjson report
+type rdResult struct {
+	Source      *rdSource       `json:"source"`
+	Diagnostics []*rdDiagnostic `json:"diagnostics"`
+}
+
+// rdDiagnostic contains info about one alert
+type rdDiagnostic struct {
+	Message     string          `json:"message"`
+	Location    rdLocation      `json:"location"`
+	Severity    string          `json:"severity"`
+	Source      *rdSource       `json:"source,omitempty"`
+	Code        *rdCode         `json:"code,omitempty"`
+	Suggestions []*rdSuggestion `json:"suggestions,omitempty"`
+}
+
+// rdSource contains info about diagnostic tool
+type rdSource struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// rdCode contains ID of check
+type rdCode struct {
+	Value string `json:"value"`
+	URL   string `json:"url,omitempty"`
+}
+
+// rdLocation contains location of problem
+type rdLocation struct {
+	Path  string   `json:"path"`
+	Range *rdRange `json:"range,omitempty"`
+}
+
+// rdRange contains range of problem (end position is exclusive)
+type rdRange struct {
+	Start rdPosition  `json:"start"`
+	End   *rdPosition `json:"end,omitempty"`
+}
+
+// rdPosition contains position in file (column is 1-based byte offset)
+type rdPosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column,omitempty"`
+}
+
+// rdSuggestion contains suggested fix
+type rdSuggestion struct {
+	Range rdRange `json:"range"`
+	Text  string  `json:"text"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// rdToolSource is info about perfecto
+var rdToolSource = &rdSource{Name: "perfecto", URL: "https://kaos.sh/perfecto"}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Report renders alerts from perfecto report
+func (r *RDJSONRenderer) Report(file string, report *check.Report) {
+	// Alerts may contain modified lines, so we need original spec
+	// data for calculating columns in bytes
+	source := report.Spec
+
+	for _, alert := range getAlerts(report) {
+		if alert.IsIgnored {
+			continue
+		}
+
+		r.addDiagnostic(r.convertAlert(file, alert, source))
+	}
+}
+
+// Perfect renders message about perfect spec
+func (r *RDJSONRenderer) Perfect(file string, report *check.Report) {}
+
+// Skipped renders message about skipped check
+func (r *RDJSONRenderer) Skipped(file string, report *check.Report) {}
+
+// Error renders global error message
+func (r *RDJSONRenderer) Error(file string, err error) {
+	r.addDiagnostic(&rdDiagnostic{
+		Message:  err.Error(),
+		Location: rdLocation{Path: file},
+		Severity: "ERROR",
+	})
+}
+
+// Flush renders all collected reports
+func (r *RDJSONRenderer) Flush() error {
+	if r.Lines {
+		return nil
+	}
+
+	result := &rdResult{Source: rdToolSource, Diagnostics: r.diagnostics}
+
+	if result.Diagnostics == nil {
+		result.Diagnostics = []*rdDiagnostic{}
+	}
+
+	data, _ := json.MarshalIndent(result, "", "  ")
+	fmt.Println(string(data))
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// addDiagnostic prints diagnostic in rdjsonl mode or saves it for rendering
+// in rdjson mode
+func (r *RDJSONRenderer) addDiagnostic(d *rdDiagnostic) {
+	if !r.Lines {
+		r.diagnostics = append(r.diagnostics, d)
+		return
+	}
+
+	d.Source = rdToolSource
+	data, _ := json.Marshal(d)
+
+	fmt.Println(string(data))
+}
+
+// convertAlert converts alert to diagnostic
+func (r *RDJSONRenderer) convertAlert(file string, alert check.Alert, source *spec.Spec) *rdDiagnostic {
+	d := &rdDiagnostic{
+		Message:  alert.Info,
+		Location: rdLocation{Path: file},
+		Severity: r.getSeverity(alert.Level),
+		Code:     &rdCode{Value: alert.ID, URL: getCheckURL(alert.ID)},
+	}
+
+	if alert.Line.Index == -1 {
+		return d
+	}
+
+	d.Location.Range = &rdRange{Start: rdPosition{Line: alert.Line.Index}}
+
+	switch {
+	case alert.EndLine != 0:
+		d.Location.Range.End = &rdPosition{Line: alert.EndLine}
+	case alert.HasSpan() && source != nil:
+		text := source.GetLine(alert.Line.Index).Text
+		d.Location.Range.Start.Column = getByteColumn(text, alert.Column)
+		d.Location.Range.End = &rdPosition{
+			Line:   alert.Line.Index,
+			Column: getByteColumn(text, alert.EndColumn+1),
+		}
+	}
+
+	// Fix replaces the whole line including line break
+	if alert.Fix != "" {
+		d.Suggestions = []*rdSuggestion{{
+			Range: rdRange{
+				Start: rdPosition{Line: alert.Line.Index, Column: 1},
+				End:   &rdPosition{Line: alert.Line.Index + 1, Column: 1},
+			},
+			Text: alert.Fix + "\n",
+		}}
+	}
+
+	return d
+}
+
+// getSeverity returns reviewdog severity for given alert level
+func (r *RDJSONRenderer) getSeverity(level uint8) string {
+	switch level {
+	case check.LEVEL_NOTICE:
+		return "INFO"
+	case check.LEVEL_WARNING:
+		return "WARNING"
+	}
+
+	return "ERROR"
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getByteColumn converts 1-based column in symbols to 1-based column in bytes
+func getByteColumn(text string, column int) int {
+	var num int
+
+	for i := range text {
+		num++
+
+		if num == column {
+			return i + 1
+		}
+	}
+
+	return len(text) + 1
+}
@@ -15,20 +15,24 @@ import (
 
 	"github.com/essentialkaos/ek/v13/fmtc"
 	"github.com/essentialkaos/ek/v13/fmtutil"
+	"github.com/essentialkaos/ek/v13/mathutil"
 	"github.com/essentialkaos/ek/v13/pager"
 	"github.com/essentialkaos/ek/v13/path"
 	"github.com/essentialkaos/ek/v13/strutil"
 
 	"github.com/essentialkaos/perfecto/check"
+	"github.com/essentialkaos/perfecto/spec"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // TerminalRenderer renders report to terminal
 type TerminalRenderer struct {
-	Format       string
-	FilenameSize int
-	UsePager     bool
+	Format         string
+	FilenameSize   int
+	Context        int // Number of lines around alert line in full report
+	UsePager       bool
+	GroupBySection bool // Group alerts by spec sections in full report
 
 	levelsPrefixes map[uint8]string
 	bgColor        map[uint8]string
@@ -36,6 +40,14 @@ type TerminalRenderer struct {
 	hlColor        map[uint8]string
 	headers        map[uint8]string
 	fallbackLevel  map[uint8]string
+
+	source *spec.Spec
+}
+
+// alertGroup contains alerts from the same section
+type alertGroup struct {
+	Title  string
+	Alerts []check.Alert
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -58,7 +70,7 @@ func (r *TerminalRenderer) Report(file string, report *check.Report) {
 	case "tiny":
 		r.renderTinyReport(file, report)
 	default:
-		r.renderFull(report)
+		r.renderFull(file, report)
 	}
 }
 
@@ -168,25 +180,31 @@ func (r *TerminalRenderer) initUI() {
 }
 
 // renderFull prints full report
-func (r *TerminalRenderer) renderFull(report *check.Report) {
+func (r *TerminalRenderer) renderFull(file string, report *check.Report) {
+	r.source = report.Spec
+
 	fmtc.NewLine()
 
-	if len(report.Notices) != 0 {
+	if r.GroupBySection {
+		r.renderSections(report)
+	}
+
+	if !r.GroupBySection && len(report.Notices) != 0 {
 		r.renderHeader(check.LEVEL_NOTICE, len(report.Notices))
 		r.renderAlerts(check.LEVEL_NOTICE, report.Notices)
 	}
 
-	if len(report.Warnings) != 0 {
+	if !r.GroupBySection && len(report.Warnings) != 0 {
 		r.renderHeader(check.LEVEL_WARNING, len(report.Warnings))
 		r.renderAlerts(check.LEVEL_WARNING, report.Warnings)
 	}
 
-	if len(report.Errors) != 0 {
+	if !r.GroupBySection && len(report.Errors) != 0 {
 		r.renderHeader(check.LEVEL_ERROR, len(report.Errors))
 		r.renderAlerts(check.LEVEL_ERROR, report.Errors)
 	}
 
-	if len(report.Criticals) != 0 {
+	if !r.GroupBySection && len(report.Criticals) != 0 {
 		r.renderHeader(check.LEVEL_CRITICAL, len(report.Criticals))
 		r.renderAlerts(check.LEVEL_CRITICAL, report.Criticals)
 	}
@@ -278,12 +296,70 @@ func (r *TerminalRenderer) renderHeader(level uint8, count int) {
 	fmtc.Printfn(fg + "│{!}")
 }
 
+// renderSections prints alerts grouped by spec sections
+func (r *TerminalRenderer) renderSections(report *check.Report) {
+	for _, group := range r.groupAlerts(report) {
+		fmtc.Printfn("{*@s} ••• %-83s{!}", fmt.Sprintf("%s (%d)", group.Title, len(group.Alerts)))
+		fmtc.Printfn("{s}│{!}")
+
+		for index, alert := range group.Alerts {
+			r.renderAlert(alert, "{s}")
+
+			if index+1 < len(group.Alerts) {
+				fmtc.Printfn("{s}│{!}")
+			}
+		}
+
+		fmtc.NewLine()
+	}
+}
+
+// groupAlerts groups all alerts from report by spec sections
+func (r *TerminalRenderer) groupAlerts(report *check.Report) []*alertGroup {
+	var result []*alertGroup
+
+	groups := map[string]*alertGroup{}
+
+	for _, alert := range getAlerts(report) {
+		title := r.getSectionTitle(alert)
+		group := groups[title]
+
+		if group == nil {
+			group = &alertGroup{Title: title}
+			groups[title] = group
+			result = append(result, group)
+		}
+
+		group.Alerts = append(group.Alerts, alert)
+	}
+
+	return result
+}
+
+// getSectionTitle returns title of section which contains alert line
+func (r *TerminalRenderer) getSectionTitle(alert check.Alert) string {
+	switch {
+	case alert.Line.Index == -1:
+		return "Global"
+	case r.source == nil:
+		return "Spec"
+	}
+
+	header := r.source.GetSectionHeader(alert.Line.Index)
+
+	if header.Index == -1 {
+		return "Preamble"
+	}
+
+	return strutil.Ellipsis(strings.TrimSpace(header.Text), 64)
+}
+
 // renderAlerts prints all alerts from given slice
 func (r *TerminalRenderer) renderAlerts(level uint8, alerts []check.Alert) {
 	totalAlerts := len(alerts)
 
 	for index, alert := range alerts {
-		r.renderAlert(alert)
+		r.renderAlert(alert, r.fgColor[level])
 
 		if index+1 < totalAlerts {
 			fmtc.Printfn(r.fgColor[level] + "│{!}")
@@ -294,10 +370,9 @@ func (r *TerminalRenderer) renderAlerts(level uint8, alerts []check.Alert) {
 }
 
 // renderAlert prints detailed info about given alert
-func (r *TerminalRenderer) renderAlert(alert check.Alert) {
+func (r *TerminalRenderer) renderAlert(alert check.Alert, lc string) {
 	fg := r.fgColor[alert.Level]
 	hl := r.hlColor[alert.Level]
-	lc := fg
 
 	if alert.IsIgnored {
 		fg = "{s}"
@@ -305,12 +380,7 @@ func (r *TerminalRenderer) renderAlert(alert check.Alert) {
 	}
 
 	fmtc.Printf(lc + "│ {!}")
-
-	if alert.Line.Index != -1 {
-		fmtc.Printf(hl+"[%d]{!} ", alert.Line.Index)
-	} else {
-		fmtc.Printf(hl + "[global]{!} ")
-	}
+	fmtc.Printf(hl+"[%s]{!} ", r.formatPosition(alert))
 
 	if alert.IsIgnored {
 		fmtc.Printf("{s}[I]{!} ")
@@ -322,21 +392,128 @@ func (r *TerminalRenderer) renderAlert(alert check.Alert) {
 		fmtc.Printfn(fg+"(rpmlint) %s{!}", alert.Info)
 	}
 
+	if r.renderSnippet(alert, lc, fg, hl) {
+		return
+	}
+
 	if alert.Line.Text != "" {
 		text := strutil.Ellipsis(alert.Line.Text, 86)
+		tc := "{s}"
+
 		if alert.IsIgnored {
-			fmtc.Printfn(lc+"│ {s-}%s{!}", text)
+			tc = "{s-}"
+		}
+
+		if alert.HasSpan() && alert.EndColumn <= strutil.Len(text) {
+			fmtc.Printfn(
+				lc+"│ "+tc+"%s{_}%s{!_}%s{!}",
+				strutil.Substr(text, 0, alert.Column-1),
+				strutil.Substr(text, alert.Column-1, alert.EndColumn-alert.Column+1),
+				strutil.Substr(text, alert.EndColumn, 86),
+			)
 		} else {
-			fmtc.Printfn(lc+"│ {s}%s{!}", text)
+			fmtc.Printfn(lc+"│ "+tc+"%s{!}", text)
 		}
 	}
 }
 
+// renderSnippet prints spec lines around alert line with marked problem
+// and suggested fix
+func (r *TerminalRenderer) renderSnippet(alert check.Alert, lc, fg, hl string) bool {
+	if r.source == nil || alert.Line.Index == -1 {
+		return false
+	}
+
+	var lines []spec.Line
+
+	first := alert.Line.Index
+	last := mathutil.Max(alert.EndLine, first)
+
+	for _, line := range r.source.Data {
+		if line.Index >= first-r.Context && line.Index <= last+r.Context {
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) == 0 {
+		return false
+	}
+
+	size := len(strconv.Itoa(lines[len(lines)-1].Index))
+	maxLen := 82 - size
+	tc := "{!}"
+
+	if alert.IsIgnored {
+		tc = "{s}"
+	}
+
+	if !r.GroupBySection {
+		section := r.getSectionTitle(alert)
+
+		if !strings.HasPrefix(section, "%") {
+			section = strings.ToLower(section)
+		}
+
+		fmtc.Printfn(lc+"│ {s-}%*s--> in %s{!}", size, "", section)
+	}
+
+	for _, line := range lines {
+		text := strutil.Ellipsis(expandTabs(line.Text), maxLen)
+
+		if line.Index < first || line.Index > last {
+			fmtc.Printfn(lc+"│ {s-}%*d │ %s{!}", size, line.Index, text)
+			continue
+		}
+
+		fmtc.Printfn(lc+"│ "+hl+"%*d{!} {s-}│{!} "+tc+"%s{!}", size, line.Index, text)
+
+		if line.Index == first && alert.HasSpan() {
+			pad, width := getCaretPosition(line.Text, alert.Column, alert.EndColumn)
+
+			if pad < maxLen {
+				width = mathutil.Min(width, maxLen-pad)
+				fmtc.Printfn(
+					lc+"│ %*s {s-}│{!} "+fg+"%s%s{!}",
+					size, "", strings.Repeat(" ", pad), strings.Repeat("^", mathutil.Max(width, 1)),
+				)
+			}
+		}
+	}
+
+	if alert.Fix != "" {
+		fmtc.Printfn(lc+"│ %*s {s-}│{!}", size, "")
+		fmtc.Printfn(lc+"│ %*s {g}help:{!} replace line with", size, "")
+		fmtc.Printfn(lc+"│ {g}%*d{!} {s-}│{!} {g}%s{!}", size, first, strutil.Ellipsis(expandTabs(alert.Fix), maxLen))
+	}
+
+	return true
+}
+
+// formatPosition returns position of alert in spec
+func (r *TerminalRenderer) formatPosition(alert check.Alert) string {
+	switch {
+	case alert.Line.Index == -1:
+		return "global"
+	case alert.Column != 0:
+		return fmt.Sprintf("%d:%d", alert.Line.Index, alert.Column)
+	}
+
+	return fmt.Sprintf("%d", alert.Line.Index)
+}
+
 // renderLinks prints links to mentioned failed checks
 func (r *TerminalRenderer) renderLinks(report *check.Report) {
-	ids := report.IDs()
+	var urls []string
+
+	for _, id := range report.IDs() {
+		url := getCheckURL(id)
 
-	if len(ids) == 0 {
+		if url != "" {
+			urls = append(urls, url)
+		}
+	}
+
+	if len(urls) == 0 {
 		return
 	}
 
@@ -344,8 +521,8 @@ func (r *TerminalRenderer) renderLinks(report *check.Report) {
 
 	fmtc.Println("\n{*}Links:{!}\n")
 
-	for _, id := range report.IDs() {
-		fmtc.Printfn(" {s}•{!} %s%s", "https://kaos.sh/perfecto/w/", id)
+	for _, url := range urls {
+		fmtc.Printfn(" {s}•{!} %s", url)
 	}
 
 	fmtc.NewLine()
@@ -412,11 +589,7 @@ func (r *TerminalRenderer) renderShortAlert(alert check.Alert) {
 		fmtc.Printf(r.levelsPrefixes[alert.Level] + " ")
 	}
 
-	if alert.Line.Index != -1 {
-		fmtc.Printf(hl+"[%d]{!} ", alert.Line.Index)
-	} else {
-		fmtc.Printf(hl + "[global]{!} ")
-	}
+	fmtc.Printf(hl+"[%s]{!} ", r.formatPosition(alert))
 
 	if alert.IsIgnored {
 		fmtc.Printf("{s}[I]{!} ")
@@ -430,3 +603,31 @@ func (r *TerminalRenderer) renderShortAlert(alert check.Alert) {
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
+
+// expandTabs replaces tabs in text by spaces
+func expandTabs(text string) string {
+	return strings.ReplaceAll(text, "\t", "    ")
+}
+
+// getCaretPosition returns offset and width of marker for given columns
+// range of text with expanded tabs
+func getCaretPosition(text string, column, endColumn int) (int, int) {
+	var pad, width int
+
+	for i, r := range []rune(text) {
+		size := 1
+
+		if r == '\t' {
+			size = 4
+		}
+
+		switch {
+		case i+1 < column:
+			pad += size
+		case i+1 <= endColumn:
+			width += size
+		}
+	}
+
+	return pad, width
+}
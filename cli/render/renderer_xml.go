@@ -8,155 +8,168 @@ package render
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
-	"fmt"
-	"unicode/utf8"
+	"encoding/xml"
 
 	"github.com/essentialkaos/perfecto/check"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-// JSONRenderer renders report in XML format
-type XMLRenderer struct{}
+// XMLRenderer renders report in XML format. Reports for multiple specs are
+// rendered as a single document with reports root element.
+type XMLRenderer struct {
+	Version string // Version of perfecto
+
+	reports []*xmlReport
+}
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-// Report renders alerts from perfecto report
-func (r *XMLRenderer) Report(file string, report *check.Report) {
-	fmt.Println(`<?xml version="1.0" encoding="UTF-8"?>`)
-	fmt.Printf(
-		"<report noLint=\"%t\" isPerfect=\"%t\" isSkipped=\"%t\">\n",
-		report.NoLint, report.IsPerfect, report.IsSkipped,
-	)
-	fmt.Println("  <alerts>")
-
-	if len(report.Notices) != 0 {
-		r.renderAlertsAsXML("notices", report.Notices)
-	}
+// xmlReports is root element of XML report for multiple specs
+type xmlReports struct {
+	XMLName xml.Name     `xml:"reports"`
+	Version string       `xml:"version,attr"`
+	Reports []*xmlReport `xml:"report"`
+}
 
-	if len(report.Warnings) != 0 {
-		r.renderAlertsAsXML("warnings", report.Warnings)
-	}
+// xmlReport is root element of XML report
+type xmlReport struct {
+	XMLName   xml.Name   `xml:"report"`
+	Version   string     `xml:"version,attr"`
+	File      string     `xml:"file,attr"`
+	NoLint    bool       `xml:"noLint,attr"`
+	IsPerfect bool       `xml:"isPerfect,attr"`
+	IsSkipped bool       `xml:"isSkipped,attr"`
+	Alerts    *xmlAlerts `xml:"alerts"`
+	Error     string     `xml:"error,omitempty"`
+}
 
-	if len(report.Errors) != 0 {
-		r.renderAlertsAsXML("errors", report.Errors)
-	}
+// xmlAlerts contains alerts grouped by level
+type xmlAlerts struct {
+	Notices   *xmlCategory `xml:"notices"`
+	Warnings  *xmlCategory `xml:"warnings"`
+	Errors    *xmlCategory `xml:"errors"`
+	Criticals *xmlCategory `xml:"criticals"`
+}
 
-	if len(report.Criticals) != 0 {
-		r.renderAlertsAsXML("criticals", report.Criticals)
-	}
+// xmlCategory contains alerts with the same level
+type xmlCategory struct {
+	Alerts []xmlAlert `xml:"alert"`
+}
+
+// xmlAlert contains info about one alert
+type xmlAlert struct {
+	ID        string   `xml:"id,attr"`
+	Level     string   `xml:"level,attr"`
+	IsIgnored bool     `xml:"ignored,attr"`
+	Info      string   `xml:"info"`
+	Line      *xmlLine `xml:"line"`
+	Fix       string   `xml:"fix,omitempty"`
+}
 
-	fmt.Println("  </alerts>")
-	fmt.Println("</report>")
+// xmlLine contains info about spec line with problem
+type xmlLine struct {
+	Index     int    `xml:"index,attr"`
+	IsIgnored bool   `xml:"ignore,attr"`
+	Column    int    `xml:"column,attr,omitempty"`
+	EndColumn int    `xml:"endColumn,attr,omitempty"`
+	EndLine   int    `xml:"endLine,attr,omitempty"`
+	Text      string `xml:",chardata"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Report renders alerts from perfecto report
+func (r *XMLRenderer) Report(file string, report *check.Report) {
+	r.reports = append(r.reports, r.convertReport(file, report))
 }
 
 // Perfect renders message about perfect spec
 func (r *XMLRenderer) Perfect(file string, report *check.Report) {
-	fmt.Println(`<?xml version="1.0" encoding="UTF-8"?>`)
-	fmt.Printf(
-		"<report noLint=\"%t\" isPerfect=\"%t\" isSkipped=\"%t\">\n",
-		report.NoLint, report.IsPerfect, report.IsSkipped,
-	)
-	fmt.Println("  <alerts></alerts>")
-	fmt.Println("</report>")
+	r.reports = append(r.reports, r.convertReport(file, report))
 }
 
-// Perfect renders message about perfect spec
+// Skipped renders message about skipped check
 func (r *XMLRenderer) Skipped(file string, report *check.Report) {
-	fmt.Println(`<?xml version="1.0" encoding="UTF-8"?>`)
-	fmt.Printf(
-		"<report noLint=\"%t\" isPerfect=\"%t\" isSkipped=\"%t\">\n",
-		report.NoLint, report.IsPerfect, report.IsSkipped,
-	)
-	fmt.Println("  <alerts></alerts>")
-	fmt.Println("</report>")
+	r.reports = append(r.reports, r.convertReport(file, report))
 }
 
 // Error renders global error message
 func (r *XMLRenderer) Error(file string, err error) {
-	fmt.Println(`<?xml version="1.0" encoding="UTF-8"?>`)
-	fmt.Println("<report>")
-	fmt.Println("  <alerts>")
-	fmt.Printf("     <error>%v</error>\n", err)
-	fmt.Println("  </alerts>")
-	fmt.Println("</report>")
+	report := r.newReport(file)
+	report.Error = err.Error()
+
+	r.reports = append(r.reports, report)
 }
 
-// ////////////////////////////////////////////////////////////////////////////////// //
+// Flush renders all collected reports
+func (r *XMLRenderer) Flush() error {
+	switch len(r.reports) {
+	case 0:
+		return nil
+	case 1:
+		return encodeXML(r.reports[0])
+	}
 
-// renderAlertsAsXML renders alerts category as XML node
-func (r *XMLRenderer) renderAlertsAsXML(category string, alerts []check.Alert) {
-	fmt.Printf("    <%s>\n", category)
+	return encodeXML(&xmlReports{Version: r.Version, Reports: r.reports})
+}
 
-	for _, alert := range alerts {
-		fmt.Printf("      <alert id=\"%s\" ignored=\"%t\">\n", alert.ID, alert.IsIgnored)
-		fmt.Printf("        <info>%s</info>\n", r.escapeStringForXML(alert.Info))
+// ////////////////////////////////////////////////////////////////////////////////// //
 
-		if alert.Line.Index != -1 {
-			fmt.Printf(
-				"        <line index=\"%d\" ignore=\"%t\">%s</line>\n",
-				alert.Line.Index, alert.Line.Ignore,
-				r.escapeStringForXML(alert.Line.Text),
-			)
-		}
+// newReport creates new XML report for given file
+func (r *XMLRenderer) newReport(file string) *xmlReport {
+	return &xmlReport{Version: r.Version, File: file}
+}
 
-		fmt.Println("      </alert>")
+// convertReport converts perfecto report to XML report
+func (r *XMLRenderer) convertReport(file string, report *check.Report) *xmlReport {
+	result := r.newReport(file)
+
+	result.NoLint = report.NoLint
+	result.IsPerfect = report.IsPerfect
+	result.IsSkipped = report.IsSkipped
+	result.Alerts = &xmlAlerts{
+		Notices:   r.convertAlerts(report.Notices),
+		Warnings:  r.convertAlerts(report.Warnings),
+		Errors:    r.convertAlerts(report.Errors),
+		Criticals: r.convertAlerts(report.Criticals),
 	}
 
-	fmt.Printf("    </%s>\n", category)
+	return result
 }
 
-// escapeStringForXML returns properly escaped XML equivalent
-// of the plain text data
-func (r *XMLRenderer) escapeStringForXML(s string) string {
-	var result, esc string
-	var last int
-
-	for i := 0; i < len(s); {
-		rn, width := utf8.DecodeRuneInString(s[i:])
-		i += width
-		switch rn {
-		case '"':
-			esc = "&#34;"
-		case '\'':
-			esc = "&#39;"
-		case '&':
-			esc = "&amp;"
-		case '<':
-			esc = "&lt;"
-		case '>':
-			esc = "&gt;"
-		case '\t':
-			esc = "&#x9;"
-		case '\n':
-			esc = "&#xA;"
-		case '\r':
-			esc = "&#xD;"
-		default:
-			if !r.isInCharacterRange(rn) || (rn == 0xFFFD && width == 1) {
-				esc = "\uFFFD"
-				break
-			}
-			continue
+// convertAlerts converts alerts to XML category node
+func (r *XMLRenderer) convertAlerts(alerts check.Alerts) *xmlCategory {
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	result := &xmlCategory{}
+
+	for _, alert := range alerts {
+		a := xmlAlert{
+			ID:        alert.ID,
+			Level:     getLevelName(alert.Level),
+			IsIgnored: alert.IsIgnored,
+			Info:      alert.Info,
+			Fix:       alert.Fix,
 		}
 
-		result += s[last : i-width]
-		result += esc
+		if alert.Line.Index != -1 {
+			a.Line = &xmlLine{
+				Index:     alert.Line.Index,
+				IsIgnored: alert.Line.Ignore,
+				EndLine:   alert.EndLine,
+				Text:      alert.Line.Text,
+			}
 
-		last = i
-	}
+			if alert.HasSpan() {
+				a.Line.Column, a.Line.EndColumn = alert.Column, alert.EndColumn
+			}
+		}
 
-	result += s[last:]
+		result.Alerts = append(result.Alerts, a)
+	}
 
 	return result
 }
-
-// isInCharacterRange decides whether the given rune is in the XML Character Range
-func (r *XMLRenderer) isInCharacterRange(rn rune) (inrange bool) {
-	return rn == 0x09 ||
-		rn == 0x0A ||
-		rn == 0x0D ||
-		rn >= 0x20 && rn <= 0xDF77 ||
-		rn >= 0xE000 && rn <= 0xFFFD ||
-		rn >= 0x10000 && rn <= 0x10FFFF
-}
@@ -58,6 +58,7 @@ install -dm 755 %{buildroot}%{_bindir}
 install -pm 755 %{name}/%{name} %{buildroot}%{_bindir}/
 
 install -pDm 644 %{name}/common/perfecto.toml %{buildroot}%{_sysconfdir}/xdg/rpmlint/perfecto.toml
+install -pDm 644 %{name}/common/perfecto-report.xsd %{buildroot}%{_datadir}/%{name}/perfecto-report.xsd
 
 %post
 if [[ -d %{_sysconfdir}/bash_completion.d ]] ; then
@@ -94,6 +95,7 @@ fi
 %doc LICENSE
 %{_bindir}/%{name}
 %{_sysconfdir}/xdg/rpmlint/perfecto.toml
+%{_datadir}/%{name}/perfecto-report.xsd
 
 ################################################################################
 
@@ -3,8 +3,10 @@ module github.com/essentialkaos/perfecto
 go 1.23.6
 
 require (
+	github.com/BurntSushi/toml v1.6.0
 	github.com/essentialkaos/check v1.4.1
 	github.com/essentialkaos/ek/v13 v13.27.3
+	mvdan.cc/sh/v3 v3.11.0
 )
 
 require (
@@ -0,0 +1,322 @@
+package spec
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/strutil"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Condition contains info about build condition (%bcond)
+type Condition struct {
+	Name       string `json:"name"`
+	Line       Line   `json:"line"`
+	IsEnabled  bool   `json:"is_enabled"`
+	HasDefault bool   `json:"has_default"`
+}
+
+// conditionBlock contains state of conditional block
+type conditionBlock struct {
+	IsEvaluated bool
+	IsActive    bool
+	IsTaken     bool
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// conditionTestRegex is regexp for build condition tests
+var conditionTestRegex = regexp.MustCompile(`%\{(with|without) ([a-zA-Z0-9_]+)\}|%\{\?(_with_|_without_|with_)([a-zA-Z0-9_]+)\}`)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// GetConditions returns all build conditions declared in spec
+func (s *Spec) GetConditions() []Condition {
+	var result []Condition
+
+	for _, line := range s.Data {
+		fields := strutil.Fields(strings.ReplaceAll(strings.TrimSpace(line.Text), "\t", " "))
+
+		if len(fields) < 2 {
+			if len(fields) == 1 && fields[0] == "%bcond" {
+				result = append(result, Condition{Line: line})
+			}
+
+			continue
+		}
+
+		switch fields[0] {
+		case "%bcond_with":
+			result = append(result, Condition{fields[1], line, false, true})
+		case "%bcond_without":
+			result = append(result, Condition{fields[1], line, true, true})
+		case "%bcond":
+			if len(fields) < 3 {
+				result = append(result, Condition{Name: fields[1], Line: line})
+				continue
+			}
+
+			value, err := strconv.Atoi(fields[2])
+			result = append(result, Condition{fields[1], line, err == nil && value != 0, err == nil})
+		}
+	}
+
+	return result
+}
+
+// Variant returns copy of spec for build variant with given enabled and
+// disabled build conditions. Lines from inactive branches of conditional
+// blocks which test only build conditions are removed. Blocks with other
+// expressions are kept as is.
+func (s *Spec) Variant(with, without []string) *Spec {
+	conds := make(map[string]bool)
+
+	for _, cond := range s.GetConditions() {
+		if cond.HasDefault {
+			conds[cond.Name] = cond.IsEnabled
+		}
+	}
+
+	for _, name := range with {
+		conds[name] = true
+	}
+
+	for _, name := range without {
+		conds[name] = false
+	}
+
+	result := &Spec{File: s.File, Targets: s.Targets, Origin: s}
+
+	var blocks []*conditionBlock
+
+	for _, line := range s.Data {
+		isActive := isBlocksActive(blocks)
+		directive := strutil.ReadField(strings.TrimSpace(line.Text), 0, true, ' ', '\t')
+
+		switch directive {
+		case "%if":
+			value, ok := evalCondition(line.Text, conds)
+			blocks = append(blocks, &conditionBlock{ok, !ok || value, !ok || value})
+
+			if ok {
+				continue
+			}
+		case "%elif":
+			if len(blocks) == 0 {
+				break
+			}
+
+			block := blocks[len(blocks)-1]
+
+			if block.IsEvaluated {
+				value, ok := evalCondition(line.Text, conds)
+				block.IsActive = !block.IsTaken && (!ok || value)
+				block.IsTaken = block.IsTaken || block.IsActive
+				continue
+			}
+		case "%else":
+			if len(blocks) == 0 {
+				break
+			}
+
+			block := blocks[len(blocks)-1]
+
+			if block.IsEvaluated {
+				block.IsActive = !block.IsTaken
+				continue
+			}
+		case "%endif":
+			if len(blocks) == 0 {
+				break
+			}
+
+			block := blocks[len(blocks)-1]
+			blocks = blocks[:len(blocks)-1]
+
+			if block.IsEvaluated {
+				continue
+			}
+		case "%ifarch", "%ifnarch", "%ifos", "%ifnos":
+			blocks = append(blocks, &conditionBlock{})
+		}
+
+		if isActive {
+			result.Data = append(result.Data, line)
+		}
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// isBlocksActive returns true if all conditional blocks are active
+func isBlocksActive(blocks []*conditionBlock) bool {
+	return !slices.ContainsFunc(blocks, func(b *conditionBlock) bool {
+		return b.IsEvaluated && !b.IsActive
+	})
+}
+
+// evalCondition evaluates %if/%elif expression with build conditions. It
+// returns false as second value if expression can't be evaluated.
+func evalCondition(text string, conds map[string]bool) (bool, bool) {
+	expr := strings.TrimSpace(text)
+	expr = strings.TrimSpace(expr[strings.IndexAny(expr, " \t")+1:])
+
+	if !conditionTestRegex.MatchString(expr) {
+		return false, false
+	}
+
+	var hasUnknown bool
+
+	expr = conditionTestRegex.ReplaceAllStringFunc(expr, func(test string) string {
+		found := conditionTestRegex.FindStringSubmatch(test)
+		kind, name := found[1], found[2]
+
+		if kind == "" {
+			kind, name = found[3], found[4]
+		}
+
+		enabled, ok := conds[name]
+
+		if !ok {
+			hasUnknown = true
+			return "0"
+		}
+
+		if kind == "without" || kind == "_without_" {
+			enabled = !enabled
+		}
+
+		if enabled {
+			return "1"
+		}
+
+		return "0"
+	})
+
+	if hasUnknown {
+		return false, false
+	}
+
+	p := &conditionParser{tokens: tokenizeCondition(expr)}
+	value, ok := p.parseOr()
+
+	if !ok || p.pos != len(p.tokens) {
+		return false, false
+	}
+
+	return value, true
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// conditionParser is parser for simple logical expressions
+type conditionParser struct {
+	tokens []string
+	pos    int
+}
+
+// tokenizeCondition splits expression into tokens
+func tokenizeCondition(expr string) []string {
+	var result []string
+
+	for i := 0; i < len(expr); i++ {
+		switch c := expr[i]; {
+		case c == ' ' || c == '\t':
+			continue
+		case c == '(' || c == ')' || (c == '!' && !strings.HasPrefix(expr[i:], "!=")):
+			result = append(result, string(c))
+		case strings.HasPrefix(expr[i:], "&&") || strings.HasPrefix(expr[i:], "||"):
+			result = append(result, expr[i:i+2])
+			i++
+		case c >= '0' && c <= '9':
+			j := i
+
+			for j < len(expr) && expr[j] >= '0' && expr[j] <= '9' {
+				j++
+			}
+
+			result = append(result, expr[i:j])
+			i = j - 1
+		default:
+			return []string{"?"}
+		}
+	}
+
+	return result
+}
+
+// parseOr parses OR expression
+func (p *conditionParser) parseOr() (bool, bool) {
+	left, ok := p.parseAnd()
+
+	for ok && p.pos < len(p.tokens) && p.tokens[p.pos] == "||" {
+		p.pos++
+
+		var right bool
+		right, ok = p.parseAnd()
+		left = left || right
+	}
+
+	return left, ok
+}
+
+// parseAnd parses AND expression
+func (p *conditionParser) parseAnd() (bool, bool) {
+	left, ok := p.parseNot()
+
+	for ok && p.pos < len(p.tokens) && p.tokens[p.pos] == "&&" {
+		p.pos++
+
+		var right bool
+		right, ok = p.parseNot()
+		left = left && right
+	}
+
+	return left, ok
+}
+
+// parseNot parses negation and terms
+func (p *conditionParser) parseNot() (bool, bool) {
+	if p.pos >= len(p.tokens) {
+		return false, false
+	}
+
+	token := p.tokens[p.pos]
+	p.pos++
+
+	switch token {
+	case "!":
+		value, ok := p.parseNot()
+		return !value, ok
+	case "(":
+		value, ok := p.parseOr()
+
+		if !ok || p.pos >= len(p.tokens) || p.tokens[p.pos] != ")" {
+			return false, false
+		}
+
+		p.pos++
+
+		return value, true
+	}
+
+	value, err := strconv.Atoi(token)
+
+	if err != nil {
+		return false, false
+	}
+
+	return value != 0, true
+}
@@ -0,0 +1,89 @@
+package spec
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/strutil"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// macroExpandRegex is regexp for macros which can be expanded
+var macroExpandRegex = regexp.MustCompile(`%\{(\??)([a-zA-Z_][a-zA-Z0-9_]*)\}|%([a-zA-Z_][a-zA-Z0-9_]*)`)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// GetMacroValues returns values of macros defined by main package tags and
+// by %global/%define at top level
+func (s *Spec) GetMacroValues() map[string]string {
+	result := make(map[string]string)
+
+	for _, line := range s.Data {
+		text := strings.TrimSpace(line.Text)
+
+		if strutil.HasPrefixAny(text, "%description", "%package", "%prep") {
+			break
+		}
+
+		fields := strutil.Fields(strings.ReplaceAll(text, "\t", " "))
+
+		switch {
+		case len(fields) < 2 || strings.HasPrefix(text, "#"):
+			continue
+		case fields[0] == "%define" || fields[0] == "%global":
+			if len(fields) > 2 && !strings.Contains(fields[1], "(") {
+				_, value, _ := strings.Cut(text, fields[1])
+				result[fields[1]] = strings.TrimSpace(value)
+			}
+		case strings.HasSuffix(fields[0], ":"):
+			tag := strings.ToLower(strings.TrimSuffix(fields[0], ":"))
+
+			switch tag {
+			case "name", "version", "release", "epoch", "url":
+				if result[tag] == "" {
+					result[tag] = fields[1]
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ExpandMacros expands macros with known values in given text
+func ExpandMacros(text string, values map[string]string) string {
+	for range 10 {
+		expanded := macroExpandRegex.ReplaceAllStringFunc(text, func(m string) string {
+			found := macroExpandRegex.FindStringSubmatch(m)
+			name := found[2] + found[3]
+			value, ok := values[name]
+
+			switch {
+			case ok:
+				return value
+			case found[1] == "?":
+				return ""
+			}
+
+			return m
+		})
+
+		if expanded == text {
+			break
+		}
+
+		text = expanded
+	}
+
+	return text
+}
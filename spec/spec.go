@@ -23,26 +23,27 @@ import (
 
 // Sections
 const (
-	SECTION_BUILD         = "build"
-	SECTION_CHANGELOG     = "changelog"
-	SECTION_CHECK         = "check"
-	SECTION_CLEAN         = "clean"
-	SECTION_DESCRIPTION   = "description"
-	SECTION_FILES         = "files"
-	SECTION_INSTALL       = "install"
-	SECTION_PACKAGE       = "package"
-	SECTION_POST          = "post"
-	SECTION_POSTTRANS     = "posttrans"
-	SECTION_POSTUN        = "postun"
-	SECTION_PRE           = "pre"
-	SECTION_PREP          = "prep"
-	SECTION_PRETRANS      = "pretrans"
-	SECTION_PREUN         = "preun"
-	SECTION_SETUP         = "setup"
-	SECTION_TRIGGERIN     = "triggerin"
-	SECTION_TRIGGERPOSTUN = "triggerpostun"
-	SECTION_TRIGGERUN     = "triggerun"
-	SECTION_VERIFYSCRIPT  = "verifyscript"
+	SECTION_BUILD                  = "build"
+	SECTION_CHANGELOG              = "changelog"
+	SECTION_CHECK                  = "check"
+	SECTION_CLEAN                  = "clean"
+	SECTION_DESCRIPTION            = "description"
+	SECTION_FILES                  = "files"
+	SECTION_GENERATE_BUILDREQUIRES = "generate_buildrequires"
+	SECTION_INSTALL                = "install"
+	SECTION_PACKAGE                = "package"
+	SECTION_POST                   = "post"
+	SECTION_POSTTRANS              = "posttrans"
+	SECTION_POSTUN                 = "postun"
+	SECTION_PRE                    = "pre"
+	SECTION_PREP                   = "prep"
+	SECTION_PRETRANS               = "pretrans"
+	SECTION_PREUN                  = "preun"
+	SECTION_SETUP                  = "setup"
+	SECTION_TRIGGERIN              = "triggerin"
+	SECTION_TRIGGERPOSTUN          = "triggerpostun"
+	SECTION_TRIGGERUN              = "triggerun"
+	SECTION_VERIFYSCRIPT           = "verifyscript"
 )
 
 const (
@@ -57,6 +58,9 @@ type Spec struct {
 	File    string   `json:"file"`
 	Data    []Line   `json:"data"`
 	Targets []string `json:"targets"`
+
+	// Origin contains original spec if spec is a build variant
+	Origin *Spec `json:"-"`
 }
 
 // Line contains line data and index
@@ -119,7 +123,7 @@ var tags = []string{
 var regexpCache = make(map[string]*regexp.Regexp)
 
 // sectionRegex is section check regexp
-var sectionRegex = regexp.MustCompile(`^%(prep|setup|build|install|check|clean|files|changelog|package|description|verifyscript|pretrans|pre|post|preun|postun|posttrans|triggerin|triggerun|triggerpostun)( |$)`)
+var sectionRegex = regexp.MustCompile(`^%(prep|setup|generate_buildrequires|build|install|check|clean|files|changelog|package|description|verifyscript|pretrans|pre|post|preun|postun|posttrans|triggerin|triggerun|triggerpostun)( |$)`)
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
@@ -171,19 +175,46 @@ func (s *Spec) GetLine(index int) Line {
 	return Line{-1, "", false}
 }
 
+// GetSectionHeader returns header of section which contains line with given
+// index. For lines from spec preamble line with index -1 is returned.
+func (s *Spec) GetSectionHeader(index int) Line {
+	header := Line{-1, "", false}
+
+	for _, line := range s.Data {
+		if isSectionHeader(line.Text) {
+			header = line
+		}
+
+		if line.Index == index {
+			return header
+		}
+	}
+
+	return Line{-1, "", false}
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // GetPackageName return package name if section is package specific
 func (s *Section) GetPackageName() string {
-	if len(s.Args) == 0 {
-		return ""
-	}
+	for i := 0; i < len(s.Args); i++ {
+		switch s.Args[i] {
+		case "-n":
+			if i+1 < len(s.Args) {
+				return s.Args[i+1]
+			}
+			return ""
+		case "-f", "-p":
+			i++ // Skip option value
+			continue
+		}
 
-	if s.Args[0] == "-n" && len(s.Args) > 1 {
-		return s.Args[1]
+		if !strings.HasPrefix(s.Args[i], "-") {
+			return s.Args[i]
+		}
 	}
 
-	return s.Args[0]
+	return ""
 }
 
 func (s *Section) IsEmpty() bool {
@@ -59,6 +59,11 @@ func (s *SpecSuite) TestParsing(c *C) {
 	c.Assert(spec.GetLine(-1), DeepEquals, Line{-1, "", false})
 	c.Assert(spec.GetLine(99), DeepEquals, Line{-1, "", false})
 	c.Assert(spec.GetLine(44), DeepEquals, Line{44, "%{__make} %{?_smp_mflags}", false})
+
+	c.Assert(spec.GetSectionHeader(44), DeepEquals, Line{43, "%build", false})
+	c.Assert(spec.GetSectionHeader(43), DeepEquals, Line{43, "%build", false})
+	c.Assert(spec.GetSectionHeader(12).Index, Equals, -1)
+	c.Assert(spec.GetSectionHeader(999).Index, Equals, -1)
 }
 
 func (s *SpecSuite) TestSections(c *C) {
@@ -166,4 +171,72 @@ func (s *SpecSuite) TestSectionPackageParsing(c *C) {
 	c.Assert(section.GetPackageName(), Equals, "test1")
 	section = Section{"test", []string{"-n", "test2"}, []Line{}, 0, 0}
 	c.Assert(section.GetPackageName(), Equals, "test2")
+	section = Section{"test", []string{"-p", "/sbin/ldconfig"}, []Line{}, 0, 0}
+	c.Assert(section.GetPackageName(), Equals, "")
+	section = Section{"test", []string{"-f", "files.list", "test3"}, []Line{}, 0, 0}
+	c.Assert(section.GetPackageName(), Equals, "test3")
+	section = Section{"test", []string{"-e", "-n", "test4"}, []Line{}, 0, 0}
+	c.Assert(section.GetPackageName(), Equals, "test4")
+}
+
+func (s *SpecSuite) TestConditions(c *C) {
+	spec, err := Read("../testdata/test_27.spec")
+
+	c.Assert(err, IsNil)
+	c.Assert(spec, NotNil)
+
+	conds := spec.GetConditions()
+
+	c.Assert(conds, HasLen, 5)
+	c.Assert(conds[0].Name, Equals, "tests")
+	c.Assert(conds[0].IsEnabled, Equals, true)
+	c.Assert(conds[1].Name, Equals, "docs")
+	c.Assert(conds[1].IsEnabled, Equals, false)
+	c.Assert(conds[2].Name, Equals, "static")
+	c.Assert(conds[2].HasDefault, Equals, true)
+	c.Assert(conds[3].Name, Equals, "debug")
+	c.Assert(conds[3].HasDefault, Equals, false)
+
+	variant := spec.Variant(nil, nil)
+
+	c.Assert(variant.Origin, Equals, spec)
+	c.Assert(variant.GetLine(24).Index, Equals, -1)
+	c.Assert(variant.GetLine(38).Index, Equals, 38)
+	c.Assert(variant.GetLine(47).Index, Equals, -1)
+
+	variant = spec.Variant([]string{"static", "debug", "docs"}, []string{"tests"})
+
+	c.Assert(variant.GetLine(24).Index, Equals, 24)
+	c.Assert(variant.GetLine(38).Index, Equals, -1)
+	c.Assert(variant.GetLine(39).Index, Equals, 39)
+	c.Assert(variant.GetLine(41).Index, Equals, -1)
+	c.Assert(variant.GetLine(43).Index, Equals, -1)
+	c.Assert(variant.GetLine(47).Index, Equals, 47)
+	c.Assert(variant.GetLine(60).Index, Equals, 60)
+	c.Assert(variant.GetSections("build"), HasLen, 1)
+
+	value, ok := evalCondition("%if (%{with a} || !%{?with_b}) && 0%{?_without_a}", map[string]bool{"a": true, "b": true})
+
+	c.Assert(ok, Equals, true)
+	c.Assert(value, Equals, false)
+
+	_, ok = evalCondition("%if %{with a} && 0%{?rhel} >= 8", map[string]bool{"a": true})
+
+	c.Assert(ok, Equals, false)
+}
+
+func (s *SpecSuite) TestMacroValues(c *C) {
+	spec, err := Read("../testdata/test_30.spec")
+
+	c.Assert(err, IsNil)
+	c.Assert(spec, NotNil)
+
+	values := spec.GetMacroValues()
+
+	c.Assert(values["name"], Equals, "perfecto")
+	c.Assert(values["version"], Equals, "1.2.3")
+	c.Assert(values["repo"], Equals, "perfecto")
+
+	c.Assert(ExpandMacros("%{repo}-%{version}%{?dist}", values), Equals, "perfecto-1.2.3")
+	c.Assert(ExpandMacros("%name-%{unknown}", values), Equals, "perfecto-%{unknown}")
 }
@@ -0,0 +1,105 @@
+################################################################################
+
+# perfecto:target ubuntu el8 el9 @rhel
+
+################################################################################
+
+%{!?_without_check: %define _with_check 1}
+
+################################################################################
+
+Summary:            Test spec for perfecto
+Name:               perfecto
+Version:            1.0.0
+Release:            0%{?dist}
+Group:              System Environment/Base
+License:            GPLv2+ and ASL 2.0
+URL:                https://domain.com
+
+BuildRoot:          %{_tmppath}/%{name}-%{version}-%{release}-root-%(%{__id_u} -n)
+
+Source0:            https://source.kaos.st/perfecto/%{name}-%{version}.tar.gz
+
+################################################################################
+
+%description
+Test spec for perfecto app.
+
+################################################################################
+
+%package magic
+
+Summary:            Test subpackage for perfecto
+Group:              System Environment/Base
+License:            BSD and MIT
+
+%description magic
+Test subpackage for perfecto app.
+
+################################################################################
+
+%package devel
+
+Summary:            Test subpackage for perfecto
+Group:              System Environment/Base
+License:            (MIT OR Apache-2.0
+
+%description devel
+Test subpackage for perfecto app.
+
+################################################################################
+
+%package docs
+
+Summary:            Test subpackage for perfecto
+Group:              System Environment/Base
+License:            CC-BY-4.0 AND FooBar-1.0 AND (GPL-2.0-or-later WITH Classpath-exception-2.0)
+
+%description docs
+Test subpackage for perfecto app.
+
+################################################################################
+
+%prep
+%setup -qn %{name}-%{version}
+
+%build
+%{__make} %{?_smp_mflags}
+
+%install
+rm -rf %{buildroot}
+
+%{make_install} PREFIX=%{buildroot}%{_prefix}
+
+%clean
+rm -rf %{buildroot}
+
+%check
+%if %{?_with_check:1}%{?_without_check:0}
+%{make} check
+%endif
+
+%post
+%{__chkconfig} --add %{name} &>/dev/null || :
+
+%preun
+%{__chkconfig} --del %{name} &> /dev/null || :
+
+%postun
+%{__chkconfig} --del %{name} &> /dev/null || :
+
+################################################################################
+
+%files
+%defattr(-,root,root,-)
+%{_bindir}/%{name}
+
+%files magic
+%defattr(-,root,root,-)
+%{_bindir}/%{name}-magic
+
+################################################################################
+
+%changelog
+* Wed Jan 24 2018 Anton Novojilov <andy@essentialkaos.com> - 1.0.0-0
+- Test changelog record
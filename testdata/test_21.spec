@@ -0,0 +1,76 @@
+################################################################################
+
+Summary:            Test spec for perfecto
+Name:               perfecto
+Version:            1.0.0
+Release:            0%{?dist}
+Group:              System Environment/Base
+License:            MIT
+URL:                https://domain.com
+
+Source0:            https://source.kaos.st/perfecto/%{name}-%{version}.tar.gz
+Source1:            %{name}.service
+Source2:            %{name}-agent.service
+
+BuildRequires:      make gcc
+
+################################################################################
+
+%description
+Test spec for perfecto app.
+
+################################################################################
+
+%package agent
+
+Summary:            Test subpackage for perfecto
+Group:              System Environment/Base
+
+%description agent
+Test subpackage for perfecto app.
+
+################################################################################
+
+%prep
+%setup -qn %{name}-%{version}
+
+%build
+%{__make} %{?_smp_mflags}
+
+%install
+rm -rf %{buildroot}
+
+%{make_install} PREFIX=%{buildroot}%{_prefix}
+
+install -pDm 644 %{SOURCE1} %{buildroot}%{_unitdir}/%{name}.service
+install -pDm 644 %{SOURCE2} %{buildroot}%{_userunitdir}/%{name}-agent.service
+
+%clean
+rm -rf %{buildroot}
+
+%post
+%systemd_post %{name}.service
+systemctl daemon-reload &>/dev/null || :
+
+%postun
+%systemd_postun %{name}.service
+
+%post agent
+systemctl --global enable %{name}-agent.service &>/dev/null || :
+
+################################################################################
+
+%files
+%defattr(-,root,root,-)
+%{_bindir}/%{name}
+%{_unitdir}/%{name}.service
+
+%files agent
+%defattr(-,root,root,-)
+%{_userunitdir}/%{name}-agent.service
+
+################################################################################
+
+%changelog
+* Wed Jan 24 2018 Anton Novojilov <andy@essentialkaos.com> - 1.0.0-0
+- Test changelog record
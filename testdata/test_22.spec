@@ -0,0 +1,93 @@
+################################################################################
+
+%define service_user  perfecto
+
+################################################################################
+
+Summary:            Test spec for perfecto
+Name:               perfecto
+Version:            1.0.0
+Release:            0%{?dist}
+Group:              System Environment/Base
+License:            MIT
+URL:                https://domain.com
+
+Source0:            https://source.kaos.st/perfecto/%{name}-%{version}.tar.gz
+Source1:            %{name}.sysusers
+
+################################################################################
+
+%description
+Test spec for perfecto app.
+
+################################################################################
+
+%package magic
+
+Summary:            Test subpackage for perfecto
+Group:              System Environment/Base
+
+Requires(pre):      shadow-utils
+
+%description magic
+Test subpackage for perfecto app.
+
+################################################################################
+
+%package sysusers
+
+Summary:            Test subpackage for perfecto
+Group:              System Environment/Base
+
+%description sysusers
+Test subpackage for perfecto app.
+
+################################################################################
+
+%prep
+%setup -qn %{name}-%{version}
+
+%build
+%{__make} %{?_smp_mflags}
+
+%install
+rm -rf %{buildroot}
+
+%{make_install} PREFIX=%{buildroot}%{_prefix}
+
+install -pDm 644 %{SOURCE1} %{buildroot}%{_sysusersdir}/%{name}.conf
+
+%clean
+rm -rf %{buildroot}
+
+%pre
+getent group %{service_user} &>/dev/null || groupadd -r %{service_user}
+getent passwd %{service_user} &>/dev/null || \
+  useradd -r -M -g %{service_user} -s /sbin/nologin %{service_user}
+exit 0
+
+%pre magic
+useradd -r magic
+
+%pre sysusers
+%sysusers_create_compat %{SOURCE1}
+
+################################################################################
+
+%files
+%defattr(-,root,root,-)
+%{_bindir}/%{name}
+
+%files magic
+%defattr(-,root,root,-)
+%{_bindir}/%{name}-magic
+
+%files sysusers
+%defattr(-,root,root,-)
+%{_sysusersdir}/%{name}.conf
+
+################################################################################
+
+%changelog
+* Wed Jan 24 2018 Anton Novojilov <andy@essentialkaos.com> - 1.0.0-0
+- Test changelog record
@@ -0,0 +1,97 @@
+################################################################################
+
+# perfecto:target ubuntu el8 el9 @rhel
+
+################################################################################
+
+%{!?_without_check: %define _with_check 1}
+
+################################################################################
+
+Summary:            Test spec for perfecto
+Name:               perfecto
+Version:            1.0.0
+Release:            0%{?dist}
+Group:              System Environment/Base
+License:            MIT
+URL:                https://domain.com
+
+BuildRoot:          %{_tmppath}/%{name}-%{version}-%{release}-root-%(%{__id_u} -n)
+
+Source0:            https://source.kaos.st/perfecto/%{name}-%{version}.tar.gz
+
+################################################################################
+
+%description
+Test spec for perfecto app.
+
+################################################################################
+
+%package magic
+
+Summary:            Test subpackage for perfecto
+Group:              System Environment/Base
+
+%description magic
+Test subpackage for perfecto app.
+
+################################################################################
+
+%prep
+%setup -qn %{name}-%{version}
+
+%build
+%{__make} %{?_smp_mflags}
+
+%install
+rm -rf %{buildroot}
+
+%{make_install} PREFIX=%{buildroot}%{_prefix}
+
+%clean
+rm -rf %{buildroot}
+
+%check
+%if %{?_with_check:1}%{?_without_check:0}
+%{make} check
+%endif
+
+%pre
+systemctl stop %{name}.service
+
+%post
+%{__chkconfig} --add %{name} &>/dev/null || :
+mkdir -p %{_localstatedir}/lib/%{name}
+
+%preun
+if [[ $1 -eq 0 ]] ; then
+  find %{_localstatedir}/cache/%{name} -name '*.tmp' -print &>/dev/null || :
+  file %{_bindir}/%{name} &>/dev/null || :
+  %{__systemctl} stop %{name}.service &>/dev/null || :
+  semodule -r %{name} &>/dev/null || :
+fi
+
+%postun
+if [ $1 -eq 0 ]; then rm -rf %{_localstatedir}/lib/%{name}-data; fi
+case "$1" in 0) userdel %{name}-data ;; esac
+if [ -d %{_tmppath}/%{name} ]; then rm -rf %{_tmppath}/%{name}; fi
+rm -rf %{_localstatedir}/lib/%{name} || :
+[[ $1 -eq 0 ]] && userdel %{name} &>/dev/null || :
+
+%postun magic -p /sbin/ldconfig
+
+################################################################################
+
+%files
+%defattr(-,root,root,-)
+%{_bindir}/%{name}
+
+%files magic
+%defattr(-,root,root,-)
+%{_bindir}/%{name}-magic
+
+################################################################################
+
+%changelog
+* Wed Jan 24 2018 Anton Novojilov <andy@essentialkaos.com> - 1.0.0-0
+- Test changelog record
@@ -0,0 +1,95 @@
+################################################################################
+
+# perfecto:target ubuntu el8 el9 @rhel
+
+################################################################################
+
+%{!?_without_check: %define _with_check 1}
+
+################################################################################
+
+Summary:            Test spec for perfecto
+Name:               perfecto
+Version:            1.0.0
+Release:            0%{?dist}
+Group:              System Environment/Base
+License:            MIT
+URL:                https://domain.com
+
+BuildRoot:          %{_tmppath}/%{name}-%{version}-%{release}-root-%(%{__id_u} -n)
+
+Source0:            https://source.kaos.st/perfecto/%{name}-%{version}.tar.gz
+
+################################################################################
+
+%description
+Test spec for perfecto app.
+
+################################################################################
+
+%package magic
+
+Summary:            Test subpackage for perfecto
+Group:              System Environment/Base
+
+%description magic
+Test subpackage for perfecto app.
+
+################################################################################
+
+%prep
+%setup -qn %{name}-%{version}
+
+%build
+%if 0%{?rhel} >= 8
+if [[ -n "%{?_smp_mflags}" ]] ; then
+%else
+if [[ -n "%{?_smp_ncpus_max}" ]] ; then
+%endif
+  %{__make} %{?_smp_mflags} CFLAGS="%{optflags} -DVERSION=\"%{version}\""
+fi
+
+echo "Build completed with 100%% success
+
+%install
+rm -rf %{buildroot}
+
+%{make_install} PREFIX=%{buildroot}%{_prefix}
+
+cat > %{buildroot}%{_sysconfdir}/%{name}.conf <<EOF
+verbose: true
+EOF
+
+cat > %{buildroot}%{_sysconfdir}/%{name}.conf <<
+verbose: false
+
+%clean
+rm -rf %{buildroot}
+
+%post
+if [[ $1 -eq 1 ]] ; then
+  %{__chkconfig} --add %{name} &>/dev/null || :
+
+%post magic -p <lua>
+if posix.access("/usr/bin/perfecto-magic") then
+  print("ok")
+end
+
+%postun
+%{__chkconfig} --del %{name} &> /dev/null || :
+
+################################################################################
+
+%files
+%defattr(-,root,root,-)
+%{_bindir}/%{name}
+
+%files magic
+%defattr(-,root,root,-)
+%{_bindir}/%{name}-magic
+
+################################################################################
+
+%changelog
+* Wed Jan 24 2018 Anton Novojilov <andy@essentialkaos.com> - 1.0.0-0
+- Test changelog record
@@ -0,0 +1,85 @@
+################################################################################
+
+Summary:            Test spec for perfecto
+Name:               perfecto
+Version:            1.0.0
+Release:            0%{?dist}
+Group:              System Environment/Base
+License:            MIT
+URL:                https://domain.com
+
+Source0:            https://source.kaos.st/perfecto/%{name}-%{version}.tar.gz
+
+BuildRequires:      make gcc
+
+################################################################################
+
+%description
+Test spec for perfecto app.
+
+################################################################################
+
+%package libs
+
+Summary:            Libraries for perfecto
+Group:              System Environment/Libraries
+
+%description libs
+Libraries for perfecto app.
+
+################################################################################
+
+%package devel
+
+Summary:            Development files for perfecto
+Group:              Development/Libraries
+
+Requires:           %{name}-libs = %{version}-%{release}
+
+%description devel
+Development files for perfecto app.
+
+################################################################################
+
+%prep
+%setup -qn %{name}-%{version}
+
+%build
+%{__make} %{?_smp_mflags}
+
+%install
+rm -rf %{buildroot}
+
+%{make_install} PREFIX=%{buildroot}%{_prefix}
+
+%clean
+rm -rf %{buildroot}
+
+%ldconfig_scriptlets libs
+
+%post
+/sbin/ldconfig
+
+################################################################################
+
+%files
+%defattr(-,root,root,-)
+%{_bindir}/%{name}
+%{_libdir}/libperfecto-core.so.*
+%{_libdir}/libperfecto-core.so
+%{_includedir}/%{name}-core.h
+
+%files libs
+%defattr(-,root,root,-)
+%{_libdir}/libperfecto.so.*
+
+%files devel
+%defattr(-,root,root,-)
+%{_includedir}/%{name}.h
+%{_libdir}/libperfecto.so
+
+################################################################################
+
+%changelog
+* Wed Jan 24 2018 Anton Novojilov <andy@essentialkaos.com> - 1.0.0-0
+- Test changelog record
@@ -0,0 +1,73 @@
+################################################################################
+
+%global service_name  perfecto
+%global service_user  perfecto
+%global unused_value  1
+%define service_group perfecto
+%global service_name  perfecto-server
+
+%bcond_without tests
+%bcond_with docs
+
+%if 0%{?rhel} >= 8
+%global build_flags -O2
+%else
+%global build_flags -O1
+%endif
+
+%global print_value() echo %1
+
+################################################################################
+
+Summary:            Test spec for perfecto
+Name:               perfecto
+Version:            1.0.0
+Release:            0%{?dist}
+Group:              System Environment/Base
+License:            MIT
+URL:                https://domain.com
+
+Source0:            https://source.kaos.st/perfecto/%{name}-%{version}.tar.gz
+
+BuildRequires:      make gcc
+
+################################################################################
+
+%description
+Test spec for perfecto app.
+
+################################################################################
+
+%prep
+%setup -qn %{name}-%{version}
+
+%build
+%{__make} %{?_smp_mflags} CFLAGS="%{build_flags}"
+%print_value %{service_group}
+
+%install
+rm -rf %{buildroot}
+
+%{make_install} PREFIX=%{buildroot}%{_prefix}
+
+install -dm 755 %{buildroot}%{_datadir}/%{servce_name}
+
+%check
+%if %{with tests}
+%{__make} check
+%endif
+
+%clean
+rm -rf %{buildroot}
+
+################################################################################
+
+%files
+%defattr(-,root,root,-)
+%attr(-,%{service_user},%{service_user}) %{_bindir}/%{name}
+
+################################################################################
+
+%changelog
+* Wed Jan 24 2018 Anton Novojilov <andy@essentialkaos.com> - 1.0.0-0
+- Test changelog record with %{undefined_macro}
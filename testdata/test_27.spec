@@ -0,0 +1,80 @@
+################################################################################
+
+%bcond_without tests
+%bcond_with docs
+%bcond static 0
+%bcond debug
+%bcond_with unused
+
+################################################################################
+
+Summary:            Test spec for perfecto
+Name:               perfecto
+Version:            1.0.0
+Release:            0%{?dist}
+Group:              System Environment/Base
+License:            MIT
+URL:                https://domain.com
+
+Source0:            https://source.kaos.st/perfecto/%{name}-%{version}.tar.gz
+
+BuildRequires:      make gcc
+
+%if %{with docs}
+BuildRequires:      pandoc
+%endif
+
+################################################################################
+
+%description
+Test spec for perfecto app.
+
+################################################################################
+
+%prep
+%setup -qn %{name}-%{version}
+
+%build
+%if %{with static} && 0%{?with_debug}
+%{__make} %{?_smp_mflags} STATIC=1 DEBUG=1
+%elif %{with static}
+%{__make} %{?_smp_mflags} STATIC=1
+%else
+%{__make} %{?_smp_mflags}
+%endif
+
+%if !%{without docs}
+%{__make} docs
+%endif
+
+%install
+rm -rf %{buildroot}
+
+%{make_install} PREFIX=%{buildroot}%{_prefix}
+
+%if 0%{?rhel} >= 8
+install -dm 755 %{buildroot}%{_datadir}/%{name}
+%endif
+
+%check
+%if %{with tests} && %{with lto}
+%{__make} check LTO=1
+%endif
+%if %{?_with_tests:1}%{!?_with_tests:0}
+%{__make} check
+%endif
+
+%clean
+rm -rf %{buildroot}
+
+################################################################################
+
+%files
+%defattr(-,root,root,-)
+%{_bindir}/%{name}
+
+################################################################################
+
+%changelog
+* Wed Jan 24 2018 Anton Novojilov <andy@essentialkaos.com> - 1.0.0-0
+- Test changelog record
@@ -0,0 +1,56 @@
+################################################################################
+
+Summary:            Test spec for perfecto
+Name:               perfecto
+Version:            1.0.0
+Release:            0%{?dist}
+Group:              System Environment/Base
+Copyright:          MIT
+URL:                https://domain.com
+
+BuildRoot:          %{_tmppath}/%{name}-%{version}-%{release}-root-%(%{__id_u} -n)
+
+Source0:            https://source.kaos.st/perfecto/%{name}-%{version}.tar.gz
+
+Patch0:             %{name}-fix.patch
+
+BuildRequires:      make gcc python3-devel
+
+PreReq:             shadow-utils
+
+################################################################################
+
+%description
+Test spec for perfecto app.
+
+################################################################################
+
+%prep
+%setup -qn %{name}-%{version}
+
+%patch0 -p1
+
+%build
+%py_requires
+%{__python} setup.py build
+%{__make} %{?_smp_mflags}
+
+%install
+rm -rf %{buildroot}
+
+%makeinstall
+
+%clean
+rm -rf %{buildroot}
+
+################################################################################
+
+%files
+%defattr(-,root,root,-)
+%{_bindir}/%{name}
+
+################################################################################
+
+%changelog
+* Wed Jan 24 2018 Anton Novojilov <andy@essentialkaos.com> - 1.0.0-0
+- Replaced %%makeinstall by %%make_install
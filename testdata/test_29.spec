@@ -0,0 +1,58 @@
+################################################################################
+
+Summary:            Test spec for perfecto
+Name:               perfecto
+Version:            1.0.0
+Release:            0%{?dist}
+Group:              System Environment/Base
+License:            MIT
+URL:                https://domain.com
+
+Source0:            https://source.kaos.st/perfecto/%{name}-%{version}.tar.gz
+
+BuildRequires:      make gcc golang python3-pip nodejs
+
+################################################################################
+
+%description
+Test spec for perfecto app.
+
+################################################################################
+
+%prep
+%setup -qn %{name}-%{version}
+
+git clone https://github.com/essentialkaos/perfecto.git
+
+%build
+curl -sLO "https://source.kaos.st/perfecto/data.tar.gz"
+go get github.com/essentialkaos/ek/v13
+%{__make} %{?_smp_mflags}
+
+npm install --offline
+%{__python3} -m pip install --no-index --find-links=vendor requests
+
+%install
+rm -rf %{buildroot}
+
+%{make_install} PREFIX=%{buildroot}%{_prefix}
+
+%{__python3} -m pip install --root %{buildroot} requests
+
+%check
+# curl https://domain.com
+npm install
+go mod edit -go=1.23
+go mod download
+
+################################################################################
+
+%files
+%defattr(-,root,root,-)
+%{_bindir}/%{name}
+
+################################################################################
+
+%changelog
+* Wed Jan 24 2018 Anton Novojilov <andy@essentialkaos.com> - 1.0.0-0
+- Test changelog record
@@ -0,0 +1,52 @@
+################################################################################
+
+%global repo  perfecto
+
+################################################################################
+
+Summary:            Test spec for perfecto
+Name:               perfecto
+Version:            1.2.3
+Release:            0%{?dist}
+Group:              System Environment/Base
+License:            MIT
+URL:                https://domain.com
+
+Source0:            https://github.com/essentialkaos/%{repo}/archive/v%{version}.tar.gz
+Source1:            https://codeload.github.com/essentialkaos/ek/tar.gz/v1.2.3
+Source2:            https://files.pythonhosted.org/packages/ab/cd/0123/requests-2.31.0.tar.gz
+Source3:            https://static.crates.io/crates/serde/serde-1.0.0.crate
+Source4:            https://gitlab.com/group/proj/repository/archive.tar.gz?ref=v%{version}
+Source5:            https://files.pythonhosted.org/packages/source/r/requests/requests-2.31.0.tar.gz
+
+BuildRequires:      make gcc
+
+################################################################################
+
+%description
+Test spec for perfecto app.
+
+################################################################################
+
+%prep
+%setup -qn %{name}-v%{version}
+
+%build
+%{__make} %{?_smp_mflags}
+
+%install
+rm -rf %{buildroot}
+
+%{make_install} PREFIX=%{buildroot}%{_prefix}
+
+################################################################################
+
+%files
+%defattr(-,root,root,-)
+%{_bindir}/%{name}
+
+################################################################################
+
+%changelog
+* Wed Jan 24 2018 Anton Novojilov <andy@essentialkaos.com> - 1.2.3-0
+- Test changelog record
@@ -0,0 +1,47 @@
+################################################################################
+
+Summary:            Test spec for perfecto
+Name:               perfecto
+Version:            1.0.0
+Release:            0%{?dist}
+Group:              System Environment/Base
+License:            MIT
+URL:                https://domain.com
+
+Source0:            https://source.kaos.st/perfecto/%{name}-%{version}.tar.gz
+
+BuildRequires:      go-rpm-macros
+
+################################################################################
+
+%description
+Test spec for perfecto app.
+
+################################################################################
+
+%prep
+%goprep
+
+%build
+go build -o %{name} .
+%gobuild -o %{name} .
+
+%install
+rm -rf %{buildroot}
+
+install -pm 755 %{name} %{buildroot}%{_bindir}/%{name}
+
+%check
+%{__go} test ./...
+
+################################################################################
+
+%files
+%defattr(-,root,root,-)
+%{_bindir}/%{name}
+
+################################################################################
+
+%changelog
+* Wed Jan 24 2018 Anton Novojilov <andy@essentialkaos.com> - 1.0.0-0
+- Test changelog record
@@ -0,0 +1,50 @@
+################################################################################
+
+Summary:            Test spec for perfecto
+Name:               perfecto
+Version:            1.0.0
+Release:            0%{?dist}
+Group:              System Environment/Base
+License:            MIT
+URL:                https://domain.com
+
+Source0:            https://source.kaos.st/perfecto/%{name}-%{version}.tar.gz
+
+BuildRequires:      python3-devel pyproject-rpm-macros
+
+################################################################################
+
+%description
+Test spec for perfecto app.
+
+################################################################################
+
+%prep
+%setup -qn %{name}-%{version}
+
+%generate_buildrequires
+echo "python3dist(setuptools)"
+
+%build
+%pyproject_wheel
+%{__python3} setup.py build
+
+%install
+rm -rf %{buildroot}
+
+%{__python3} -m pip install --root %{buildroot} --no-deps .
+
+%check
+%{__python3} -c 'import perfecto'
+
+################################################################################
+
+%files
+%defattr(-,root,root,-)
+%{python3_sitelib}/%{name}
+
+################################################################################
+
+%changelog
+* Wed Jan 24 2018 Anton Novojilov <andy@essentialkaos.com> - 1.0.0-0
+- Test changelog record
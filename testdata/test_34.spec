@@ -0,0 +1,48 @@
+################################################################################
+
+Summary:            Test spec for perfecto
+Name:               perfecto
+Version:            1.0.0
+Release:            0%{?dist}
+Group:              System Environment/Base
+License:            MIT
+URL:                https://domain.com
+
+Source0:            https://source.kaos.st/perfecto/%{name}-%{version}.tar.gz
+
+BuildRequires:      nodejs-packaging
+
+################################################################################
+
+%description
+Test spec for perfecto app.
+
+################################################################################
+
+%prep
+%setup -qn %{name}-%{version}
+
+%build
+npm install --offline
+
+%install
+rm -rf %{buildroot}
+
+mkdir -p %{buildroot}/usr/lib/node_modules/%{name}
+cp -pr lib package.json %{buildroot}%{nodejs_sitelib}/%{name}/
+%nodejs_symlink_deps
+
+%check
+# no tests
+
+################################################################################
+
+%files
+%defattr(-,root,root,-)
+%{nodejs_sitelib}/%{name}
+
+################################################################################
+
+%changelog
+* Wed Jan 24 2018 Anton Novojilov <andy@essentialkaos.com> - 1.0.0-0
+- Test changelog record
@@ -0,0 +1,50 @@
+################################################################################
+
+Summary:            Test spec for perfecto
+Name:               perfecto
+Version:            1.0.0
+Release:            0%{?dist}
+Group:              System Environment/Base
+License:            MIT
+URL:                https://domain.com
+
+Source0:            https://source.kaos.st/perfecto/%{name}-%{version}.tar.gz
+
+BuildRequires:      make gcc
+
+################################################################################
+
+%description
+Test spec for perfecto app.
+
+################################################################################
+
+%prep
+%setup -qn %{name}-%{version}
+
+%build
+# TODO: enable tests
+%{__make} %{?_smp_mflags}
+
+%install
+rm -rf %{buildroot}
+
+install -dm 755 %{buildroot}/opt/%{name}
+install -dm 755 %{buildroot}/opt/legacy
+# /opt is used for compatibility
+
+################################################################################
+
+%files
+%defattr(-,root,root,-)
+/opt/%{name}
+/opt/legacy
+%ifarch x86_64
+%{_bindir}/%{name}
+%endif
+
+################################################################################
+
+%changelog
+* Wed Jan 24 2018 Anton Novojilov <andy@essentialkaos.com> - 1.0.0-0
+- Test changelog record
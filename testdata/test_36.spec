@@ -0,0 +1,48 @@
+################################################################################
+
+%global ver  1.2.3
+
+################################################################################
+
+Summary:            Test spec for perfecto
+Name:               perfecto
+Version:            %{ver}
+Release:            0%{?dist}
+Group:              System Environment/Base
+License:            MIT
+URL:                https://github.com/essentialkaos/perfecto
+
+Source0:            %{url}/archive/v%{version}/%{name}-%{version}.tar.gz
+Source1:            https://codeload.github.com/essentialkaos/ek/tar.gz/v1.2.3
+
+BuildRequires:      make gcc
+
+################################################################################
+
+%description
+Test spec for perfecto app.
+
+################################################################################
+
+%prep
+%setup -qn %{name}-v%{version}
+
+%build
+%{__make} %{?_smp_mflags}
+
+%install
+rm -rf %{buildroot}
+
+%{make_install} PREFIX=%{buildroot}%{_prefix}
+
+################################################################################
+
+%files
+%defattr(-,root,root,-)
+%{_bindir}/%{name}
+
+################################################################################
+
+%changelog
+* Wed Jan 24 2018 Anton Novojilov <andy@essentialkaos.com> - 1.2.3-0
+- Test changelog record
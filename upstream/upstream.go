@@ -0,0 +1,398 @@
+package upstream
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/essentialkaos/ek/v13/req"
+	"github.com/essentialkaos/ek/v13/strutil"
+
+	"github.com/essentialkaos/perfecto/spec"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Project types
+const (
+	TYPE_GITHUB = "github"
+	TYPE_GITLAB = "gitlab"
+	TYPE_PYPI   = "pypi"
+	TYPE_CRATES = "crates"
+	TYPE_NPM    = "npm"
+)
+
+// DEFAULT_TIMEOUT is default timeout for feed requests
+const DEFAULT_TIMEOUT = 10 * time.Second
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Project contains info about upstream project
+type Project struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// Feed is source of info about the latest releases of upstream projects
+type Feed struct {
+	URL     string        // Endpoint URL template (public APIs are used if empty)
+	Engine  *req.Engine   // Request engine (req.Global if nil)
+	Timeout time.Duration // Request timeout
+}
+
+// Result contains result of upstream version check
+type Result struct {
+	File       string   `json:"file"`
+	Project    *Project `json:"project,omitempty"`
+	Current    string   `json:"current"`
+	Latest     string   `json:"latest,omitempty"`
+	IsOutdated bool     `json:"is_outdated"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// feedResponse contains fields with version info from supported APIs
+type feedResponse struct {
+	Version string `json:"version"`
+	TagName string `json:"tag_name"`
+
+	Info struct {
+		Version string `json:"version"`
+	} `json:"info"`
+
+	Crate struct {
+		MaxStableVersion string `json:"max_stable_version"`
+	} `json:"crate"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// apiURLs contains URL templates of public APIs
+var apiURLs = map[string]string{
+	TYPE_GITHUB: "https://api.github.com/repos/{project}/releases/latest",
+	TYPE_GITLAB: "https://gitlab.com/api/v4/projects/{id}/releases/permalink/latest",
+	TYPE_PYPI:   "https://pypi.org/pypi/{project}/json",
+	TYPE_CRATES: "https://crates.io/api/v1/crates/{project}",
+	TYPE_NPM:    "https://registry.npmjs.org/{project}/latest",
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Extract extracts info about upstream project from Source0 and URL tags
+func Extract(s *spec.Spec) *Project {
+	var urls []string
+
+	values := s.GetMacroValues()
+
+	for _, line := range s.GetSources() {
+		tag := strutil.ReadField(strings.TrimSpace(line.Text), 0, true, ' ', '\t')
+
+		if tag == "Source0:" || tag == "Source:" {
+			source := strutil.ReadField(strings.TrimSpace(line.Text), 1, true, ' ', '\t')
+			urls = append(urls, spec.ExpandMacros(source, values))
+		}
+	}
+
+	urls = append(urls, spec.ExpandMacros(values["url"], values))
+
+	for _, u := range urls {
+		project := ParseURL(u)
+
+		if project != nil {
+			return project
+		}
+	}
+
+	return nil
+}
+
+// ParseURL parses project or archive URL and returns info about project
+func ParseURL(rawURL string) *Project {
+	u, err := url.Parse(rawURL)
+
+	if err != nil || u.Host == "" || strings.Contains(rawURL, "%") {
+		return nil
+	}
+
+	path := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	if len(path) < 2 {
+		return nil
+	}
+
+	switch strings.TrimPrefix(u.Host, "www.") {
+	case "github.com", "codeload.github.com":
+		return &Project{TYPE_GITHUB, path[0] + "/" + strings.TrimSuffix(path[1], ".git")}
+
+	case "api.github.com":
+		if path[0] == "repos" && len(path) > 2 {
+			return &Project{TYPE_GITHUB, path[1] + "/" + path[2]}
+		}
+
+	case "gitlab.com":
+		for i, part := range path {
+			if part == "-" || part == "repository" {
+				path = path[:i]
+				break
+			}
+		}
+
+		if len(path) > 1 {
+			return &Project{TYPE_GITLAB, strings.TrimSuffix(strings.Join(path, "/"), ".git")}
+		}
+
+	case "pypi.org", "pypi.io", "pypi.python.org", "files.pythonhosted.org":
+		switch {
+		case path[0] == "project", path[0] == "pypi":
+			return &Project{TYPE_PYPI, path[1]}
+		case path[0] == "packages" && path[1] == "source" && len(path) > 3:
+			return &Project{TYPE_PYPI, path[3]}
+		}
+
+	case "crates.io", "static.crates.io":
+		switch {
+		case path[0] == "crates":
+			return &Project{TYPE_CRATES, path[1]}
+		case path[0] == "api" && len(path) > 3 && path[2] == "crates":
+			return &Project{TYPE_CRATES, path[3]}
+		}
+
+	case "npmjs.com":
+		if path[0] == "package" {
+			return &Project{TYPE_NPM, getNPMPackageName(path[1:])}
+		}
+
+	case "registry.npmjs.org", "registry.yarnpkg.com":
+		return &Project{TYPE_NPM, getNPMPackageName(path)}
+	}
+
+	return nil
+}
+
+// IsNewer returns true if latest version is newer than current one. Versions
+// are compared in the same way as RPM does.
+func IsNewer(current, latest string) bool {
+	return compareVersions(latest, current) > 0
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Check checks if version of given spec is outdated
+func (f *Feed) Check(s *spec.Spec) *Result {
+	values := s.GetMacroValues()
+	result := &Result{
+		File:    s.File,
+		Current: spec.ExpandMacros(values["version"], values),
+		Project: Extract(s),
+	}
+
+	if result.Project == nil {
+		result.Error = "Can't find upstream project in URL and Source0 tags"
+		return result
+	}
+
+	latest, err := f.Latest(result.Project)
+
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Latest = latest
+	result.IsOutdated = IsNewer(result.Current, latest)
+
+	return result
+}
+
+// Latest returns the latest released version of given project
+func (f *Feed) Latest(p *Project) (string, error) {
+	engine := f.Engine
+	timeout := f.Timeout
+
+	if engine == nil {
+		engine = req.Global
+	}
+
+	if timeout <= 0 {
+		timeout = DEFAULT_TIMEOUT
+	}
+
+	feedURL := f.getURL(p)
+
+	if feedURL == "" {
+		return "", fmt.Errorf("Project type %q is not supported", p.Type)
+	}
+
+	resp, err := engine.Get(req.Request{
+		URL:            feedURL,
+		Accept:         req.CONTENT_TYPE_JSON,
+		Timeout:        timeout,
+		FollowRedirect: true,
+		AutoDiscard:    true,
+	})
+
+	if err != nil {
+		return "", fmt.Errorf("Can't send request to feed: %w", err)
+	}
+
+	if resp.StatusCode != 200 {
+		return "", fmt.Errorf("Feed returned status code %d", resp.StatusCode)
+	}
+
+	data := &feedResponse{}
+	err = resp.JSON(data)
+
+	if err != nil {
+		return "", fmt.Errorf("Can't decode feed response: %w", err)
+	}
+
+	version := data.GetVersion()
+
+	if version == "" {
+		return "", fmt.Errorf("Feed response doesn't contain version info")
+	}
+
+	return version, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// GetVersion returns version from response
+func (r *feedResponse) GetVersion() string {
+	var version string
+
+	switch {
+	case r.Version != "":
+		version = r.Version
+	case r.TagName != "":
+		version = r.TagName
+	case r.Info.Version != "":
+		version = r.Info.Version
+	case r.Crate.MaxStableVersion != "":
+		version = r.Crate.MaxStableVersion
+	}
+
+	if len(version) > 1 && (version[0] == 'v' || version[0] == 'V') && isDigit(version[1]) {
+		version = version[1:]
+	}
+
+	return version
+}
+
+// getURL returns URL of feed endpoint for given project
+func (f *Feed) getURL(p *Project) string {
+	template := apiURLs[p.Type]
+
+	if f.URL != "" {
+		template = f.URL
+
+		if !strings.Contains(template, "{") {
+			template = strings.TrimRight(template, "/") + "/{type}/{project}"
+		}
+	}
+
+	if template == "" {
+		return ""
+	}
+
+	return strings.NewReplacer(
+		"{type}", p.Type,
+		"{project}", p.Name,
+		"{id}", url.PathEscape(p.Name),
+	).Replace(template)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getNPMPackageName returns name of npm package from URL path
+func getNPMPackageName(path []string) string {
+	if strings.HasPrefix(path[0], "@") && len(path) > 1 {
+		return path[0] + "/" + path[1]
+	}
+
+	return path[0]
+}
+
+// compareVersions compares two versions using RPM algorithm
+func compareVersions(v1, v2 string) int {
+	for {
+		v1 = strings.TrimLeftFunc(v1, isSeparator)
+		v2 = strings.TrimLeftFunc(v2, isSeparator)
+
+		if v1 == "" || v2 == "" {
+			break
+		}
+
+		s1, s2 := readSegment(v1), readSegment(v2)
+		isNum1, isNum2 := isDigit(s1[0]), isDigit(s2[0])
+
+		switch {
+		case isNum1 && !isNum2:
+			return 1
+		case !isNum1 && isNum2:
+			return -1
+		case isNum1:
+			n1, n2 := strings.TrimLeft(s1, "0"), strings.TrimLeft(s2, "0")
+
+			if len(n1) != len(n2) {
+				return sign(len(n1) - len(n2))
+			}
+
+			if n1 != n2 {
+				return strings.Compare(n1, n2)
+			}
+		case s1 != s2:
+			return strings.Compare(s1, s2)
+		}
+
+		v1, v2 = v1[len(s1):], v2[len(s2):]
+	}
+
+	switch {
+	case v1 == "" && v2 == "":
+		return 0
+	case v1 == "":
+		return -1
+	}
+
+	return 1
+}
+
+// readSegment reads numeric or alphabetic segment from the beginning of version
+func readSegment(v string) string {
+	isNum := isDigit(v[0])
+
+	for i := 1; i < len(v); i++ {
+		if isSeparator(rune(v[i])) || isDigit(v[i]) != isNum {
+			return v[:i]
+		}
+	}
+
+	return v
+}
+
+// isSeparator returns true if given rune is not a letter or digit
+func isSeparator(r rune) bool {
+	return !(r >= '0' && r <= '9') && !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z')
+}
+
+// isDigit returns true if given byte is digit
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// sign returns sign of given number
+func sign(v int) int {
+	if v > 0 {
+		return 1
+	}
+
+	return -1
+}
@@ -0,0 +1,134 @@
+package upstream
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/essentialkaos/perfecto/spec"
+
+	chk "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { chk.TestingT(t) }
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+type UpstreamSuite struct{}
+
+var _ = chk.Suite(&UpstreamSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *UpstreamSuite) TestURLParsing(c *chk.C) {
+	c.Assert(ParseURL("https://github.com/essentialkaos/perfecto/archive/v1.0.0.tar.gz"), chk.DeepEquals, &Project{TYPE_GITHUB, "essentialkaos/perfecto"})
+	c.Assert(ParseURL("https://github.com/essentialkaos/perfecto.git"), chk.DeepEquals, &Project{TYPE_GITHUB, "essentialkaos/perfecto"})
+	c.Assert(ParseURL("https://codeload.github.com/essentialkaos/ek/tar.gz/v1.0.0"), chk.DeepEquals, &Project{TYPE_GITHUB, "essentialkaos/ek"})
+	c.Assert(ParseURL("https://gitlab.com/group/sub/proj/-/archive/v1.0.0/proj-v1.0.0.tar.gz"), chk.DeepEquals, &Project{TYPE_GITLAB, "group/sub/proj"})
+	c.Assert(ParseURL("https://gitlab.com/group/proj/repository/archive.tar.gz?ref=v1.0.0"), chk.DeepEquals, &Project{TYPE_GITLAB, "group/proj"})
+	c.Assert(ParseURL("https://pypi.org/project/requests/"), chk.DeepEquals, &Project{TYPE_PYPI, "requests"})
+	c.Assert(ParseURL("https://files.pythonhosted.org/packages/source/r/requests/requests-2.31.0.tar.gz"), chk.DeepEquals, &Project{TYPE_PYPI, "requests"})
+	c.Assert(ParseURL("https://crates.io/crates/serde"), chk.DeepEquals, &Project{TYPE_CRATES, "serde"})
+	c.Assert(ParseURL("https://crates.io/api/v1/crates/serde/1.0.0/download"), chk.DeepEquals, &Project{TYPE_CRATES, "serde"})
+	c.Assert(ParseURL("https://www.npmjs.com/package/@babel/core"), chk.DeepEquals, &Project{TYPE_NPM, "@babel/core"})
+	c.Assert(ParseURL("https://registry.npmjs.org/left-pad/-/left-pad-1.3.0.tgz"), chk.DeepEquals, &Project{TYPE_NPM, "left-pad"})
+
+	c.Assert(ParseURL("https://domain.com/project"), chk.IsNil)
+	c.Assert(ParseURL("https://github.com/essentialkaos"), chk.IsNil)
+	c.Assert(ParseURL("https://github.com/%{name}/%{name}"), chk.IsNil)
+	c.Assert(ParseURL("perfecto-1.0.0.tar.gz"), chk.IsNil)
+}
+
+func (s *UpstreamSuite) TestExtract(c *chk.C) {
+	sp, err := spec.Read("../testdata/test_30.spec")
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(Extract(sp), chk.DeepEquals, &Project{TYPE_GITHUB, "essentialkaos/perfecto"})
+
+	sp, err = spec.Read("../testdata/test.spec")
+
+	c.Assert(err, chk.IsNil)
+	c.Assert(Extract(sp), chk.IsNil)
+}
+
+func (s *UpstreamSuite) TestVersionComparison(c *chk.C) {
+	c.Assert(IsNewer("1.2.3", "1.2.4"), chk.Equals, true)
+	c.Assert(IsNewer("1.2.3", "1.10.0"), chk.Equals, true)
+	c.Assert(IsNewer("1.2.3", "1.2.3.1"), chk.Equals, true)
+	c.Assert(IsNewer("1.2", "1.2.a"), chk.Equals, true)
+	c.Assert(IsNewer("1.2.3", "1.2.3"), chk.Equals, false)
+	c.Assert(IsNewer("1.2.3", "01.2.3"), chk.Equals, false)
+	c.Assert(IsNewer("1.10.0", "1.9.9"), chk.Equals, false)
+	c.Assert(IsNewer("1.2.3", "1.2"), chk.Equals, false)
+	c.Assert(IsNewer("1.2a", "1.2"), chk.Equals, false)
+	c.Assert(IsNewer("1.2.b", "1.2.a"), chk.Equals, false)
+}
+
+func (s *UpstreamSuite) TestFeed(c *chk.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/github/essentialkaos/perfecto":
+			fmt.Fprint(w, `{"tag_name":"v1.3.0"}`)
+		case "/pypi/requests":
+			fmt.Fprint(w, `{"info":{"version":"2.31.0"}}`)
+		case "/crates/serde":
+			fmt.Fprint(w, `{"crate":{"max_stable_version":"1.0.0"}}`)
+		case "/npm/left-pad":
+			fmt.Fprint(w, `{"name":"left-pad"}`)
+		case "/npm/broken":
+			fmt.Fprint(w, `{`)
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+
+	defer server.Close()
+
+	feed := &Feed{URL: server.URL}
+
+	v, err := feed.Latest(&Project{TYPE_GITHUB, "essentialkaos/perfecto"})
+	c.Assert(err, chk.IsNil)
+	c.Assert(v, chk.Equals, "1.3.0")
+
+	v, err = feed.Latest(&Project{TYPE_PYPI, "requests"})
+	c.Assert(err, chk.IsNil)
+	c.Assert(v, chk.Equals, "2.31.0")
+
+	v, err = feed.Latest(&Project{TYPE_CRATES, "serde"})
+	c.Assert(err, chk.IsNil)
+	c.Assert(v, chk.Equals, "1.0.0")
+
+	_, err = feed.Latest(&Project{TYPE_NPM, "left-pad"})
+	c.Assert(err, chk.ErrorMatches, "Feed response doesn't contain version info")
+	_, err = feed.Latest(&Project{TYPE_NPM, "broken"})
+	c.Assert(err, chk.ErrorMatches, "Can't decode feed response: .*")
+	_, err = feed.Latest(&Project{TYPE_GITLAB, "group/proj"})
+	c.Assert(err, chk.ErrorMatches, "Feed returned status code 404")
+	_, err = (&Feed{}).Latest(&Project{"unknown", "test"})
+	c.Assert(err, chk.ErrorMatches, `Project type "unknown" is not supported`)
+
+	sp, err := spec.Read("../testdata/test_30.spec")
+	c.Assert(err, chk.IsNil)
+
+	result := feed.Check(sp)
+
+	c.Assert(result.Error, chk.Equals, "")
+	c.Assert(result.Current, chk.Equals, "1.2.3")
+	c.Assert(result.Latest, chk.Equals, "1.3.0")
+	c.Assert(result.IsOutdated, chk.Equals, true)
+
+	feed = &Feed{URL: server.URL + "/{type}/{project}"}
+	c.Assert(feed.getURL(&Project{TYPE_GITLAB, "group/proj"}), chk.Equals, server.URL+"/gitlab/group/proj")
+	feed = &Feed{}
+	c.Assert(feed.getURL(&Project{TYPE_GITLAB, "group/proj"}), chk.Equals, "https://gitlab.com/api/v4/projects/group%2Fproj/releases/permalink/latest")
+}